@@ -8,6 +8,7 @@ import (
 	"central-desktop/internal/util"
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
@@ -34,6 +35,10 @@ func (a *App) startup(ctx context.Context) {
 
 func (a *App) shutdown(_ context.Context) {
 	a.StopAllApplications()
+	a.deps.Services.AlertService.Stop()
+	a.deps.Services.GitStatusMonitor.Stop()
+	a.deps.Services.GitService.CancelAll()
+	a.deps.Services.BuildService.CancelAll()
 	if a.closeLogs != nil {
 		_ = a.closeLogs()
 	}
@@ -102,7 +107,7 @@ func (a *App) PickJarFile() (res string) {
 }
 
 func (a *App) PickBaseApplicationFolder() (res *dto.PickBaseApplicationFolderDTO) {
-	res, err := util.PickBaseApplicationFolder(a.ctx)
+	res, err := util.PickBaseApplicationFolder(a.ctx, a.deps.Services.SettingsService.Settings.JarScanIgnore)
 	if err != nil {
 		a.logError(err)
 	}
@@ -144,6 +149,44 @@ func (a *App) StopLogStreaming() {
 	a.deps.Services.CentralService.StopLog(a.deps.LogTailer)
 }
 
+func (a *App) StartMergedLogStreaming(appNames []string, traceID string) {
+	err := a.deps.Services.CentralService.StartMergedLog(a.deps.MergedLogTailer, appNames, traceID)
+	if err != nil {
+		a.logError(err)
+	}
+}
+
+func (a *App) StopMergedLogStreaming() {
+	a.deps.Services.CentralService.StopMergedLog(a.deps.MergedLogTailer)
+}
+
+func (a *App) GetAlertHistory() []domain.LogAlert {
+	return a.deps.Services.AlertService.GetHistory()
+}
+
+func (a *App) ClearAlertHistory() {
+	a.deps.Services.AlertService.ClearHistory()
+}
+
+func (a *App) ExportDiagnostics(appNames []string) (res string) {
+	res, err := util.PickDiagnosticsArchivePath(a.ctx)
+	if err != nil {
+		a.logError(err)
+		return ""
+	}
+	if res == "" {
+		return
+	}
+
+	err = a.deps.Services.CentralService.ExportDiagnostics(appNames, res)
+	if err != nil {
+		a.logError(err)
+		return ""
+	}
+	util.NotifySuccess(a.ctx, "Диагностика", fmt.Sprintf("Архив сохранён: %s", res))
+	return
+}
+
 func (a *App) GetGitBranches(appName string, fetch bool) (res *domain.Branches) {
 	res, err := a.deps.Services.CentralService.GetGitBranches(appName, fetch)
 	if err != nil {
@@ -153,44 +196,353 @@ func (a *App) GetGitBranches(appName string, fetch bool) (res *domain.Branches)
 	return
 }
 
+func (a *App) GetGitStatus(appName string) (res *domain.RepoStatus) {
+	res, err := a.deps.Services.CentralService.GetGitStatus(appName)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) PullRepository(appName string, rebase bool) (res *domain.PullResult) {
+	res, err := a.deps.Services.CentralService.PullRepository(appName, rebase)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) UpdateAllRepositories(rebase bool) []dto.RepoUpdateResultDTO {
+	return a.deps.Services.CentralService.UpdateAllRepositories(rebase)
+}
+
+func (a *App) SwitchFeatureBranch(req *dto.FeatureBranchRequestDTO) (res []dto.FeatureBranchResultDTO) {
+	res, err := a.deps.Services.CentralService.SwitchFeatureBranch(req)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) GetCommitLog(appName string, rev string, skip int, limit int) (res *domain.CommitPage) {
+	res, err := a.deps.Services.CentralService.GetCommitLog(appName, rev, skip, limit)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) GetIncomingChanges(appName string, skip int, limit int) (res *domain.CommitPage) {
+	res, err := a.deps.Services.CentralService.GetIncomingChanges(appName, skip, limit)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) GetIncomingFiles(appName string) (res []domain.ChangedFile) {
+	res, err := a.deps.Services.CentralService.GetIncomingFiles(appName)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) GetCommitDetails(appName string, hash string) (res *domain.CommitDetails) {
+	res, err := a.deps.Services.CentralService.GetCommitDetails(appName, hash)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) GetFileDiff(appName string, rev string, path string) (res string) {
+	res, err := a.deps.Services.CentralService.GetFileDiff(appName, rev, path)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
 func (a *App) ScanJars(baseDir string) (res []string) {
-	res, err := util.ScanJars(baseDir)
+	candidates, err := a.deps.Services.JarScanService.Scan(baseDir)
 	if err != nil {
 		a.logError(err)
 	}
-	if len(res) < 1 {
+	res = util.JarCandidatePaths(candidates)
+	if err == nil && len(res) < 1 {
 		err = errors.New("в выбранной директории не найдено ни одного .jar файла")
 		a.logError(err)
 	}
 	return
 }
 
+// ScanJarCandidates - найденные jar с признаками ранжирования; прерывается CancelJarScan.
+func (a *App) ScanJarCandidates(baseDir string) (res []domain.JarCandidate) {
+	res, err := a.deps.Services.JarScanService.Scan(baseDir)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) CancelJarScan() bool {
+	return a.deps.Services.JarScanService.Cancel()
+}
+
 func (a *App) CheckoutBranch(appName string, branch string) {
-	err := a.deps.Services.CentralService.CheckoutBranch(appName, branch)
+	_, err := a.deps.Services.CentralService.CheckoutBranch(appName, branch, domain.CheckoutOptions{})
 	if err != nil {
 		a.logError(err)
 	}
 	return
 }
 
+// CheckoutBranchWithOptions - checkout с выбором remote для отслеживания и auto-stash.
+// Если ветка есть на нескольких remote и remote не выбран, в результате приходят CandidateRemotes.
+func (a *App) CheckoutBranchWithOptions(appName string, branch string, opts domain.CheckoutOptions) (res *domain.CheckoutResult) {
+	res, err := a.deps.Services.CentralService.CheckoutBranch(appName, branch, opts)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) ListStashes(appName string) (res []domain.StashEntry) {
+	res, err := a.deps.Services.CentralService.ListStashes(appName)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) ApplyStash(appName string, hash string) (res []string) {
+	res, err := a.deps.Services.CentralService.ApplyStash(appName, hash)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) DropStash(appName string, hash string) {
+	err := a.deps.Services.CentralService.DropStash(appName, hash)
+	if err != nil {
+		a.logError(err)
+	}
+}
+
+// CreateBranch создаёт ветку от HEAD или указанного ref (ветка, тег, коммит),
+// опционально переключается на неё и публикует с upstream.
+func (a *App) CreateBranch(appName string, opts domain.CreateBranchOptions) {
+	err := a.deps.Services.CentralService.CreateBranch(appName, opts)
+	if err != nil {
+		a.logError(err)
+	}
+}
+
+// DeleteBranch удаляет только слитую локальную ветку (git branch -d).
+func (a *App) DeleteBranch(appName string, branch string) {
+	err := a.deps.Services.CentralService.DeleteBranch(appName, branch)
+	if err != nil {
+		a.logError(err)
+	}
+}
+
+func (a *App) ListMergedBranches(appName string) (res []string) {
+	res, err := a.deps.Services.CentralService.ListMergedBranches(appName)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+// PruneGoneBranches удаляет локальные ветки, upstream которых удалён на remote.
+// С dryRun возвращает только список кандидатов.
+func (a *App) PruneGoneBranches(appName string, dryRun bool) (res *domain.PruneResult) {
+	res, err := a.deps.Services.CentralService.PruneGoneBranches(appName, dryRun)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) ListTags(appName string) (res []string) {
+	res, err := a.deps.Services.CentralService.ListTags(appName)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+// CheckoutTag переключает репозиторий на тег (detached HEAD, см. RepoStatus.DetachedAt).
+func (a *App) CheckoutTag(appName string, tag string) {
+	err := a.deps.Services.CentralService.CheckoutTag(appName, tag)
+	if err != nil {
+		a.logError(err)
+	}
+}
+
+// CreateWorktree создаёт git worktree для ветки и добавляет его как отдельное приложение.
+func (a *App) CreateWorktree(req *dto.WorktreeRequestDTO) (res *dto.CentralInfoDTO) {
+	res, err := a.deps.Services.CentralService.CreateWorktree(req)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+// RemoveWorktree удаляет worktree-приложение вместе с папкой worktree.
+func (a *App) RemoveWorktree(appName string, force bool) (res *dto.CentralInfoDTO) {
+	res, err := a.deps.Services.CentralService.RemoveWorktree(appName, force)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) ListWorktrees(appName string) (res []domain.Worktree) {
+	res, err := a.deps.Services.CentralService.ListWorktrees(appName)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+// BuildApplication собирает приложение Maven или Gradle с параметрами BuildOptions;
+// вывод приходит событиями "build:output".
+func (a *App) BuildApplication(appName string) (res *domain.BuildResult) {
+	res, err := a.deps.Services.CentralService.BuildApplication(appName)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) CancelBuild(appName string) {
+	a.deps.Services.CentralService.CancelBuild(appName)
+}
+
+func (a *App) GetBuildResult(appName string) *domain.BuildResult {
+	return a.deps.Services.CentralService.GetBuildResult(appName)
+}
+
+func (a *App) GetBuildQueue() []domain.BuildQueueItem {
+	return a.deps.Services.CentralService.GetBuildQueue()
+}
+
+// GetBuildHistory - последние сборки приложения (пустое appName - всех приложений).
+func (a *App) GetBuildHistory(appName string) []domain.BuildResult {
+	return a.deps.Services.CentralService.GetBuildHistory(appName)
+}
+
+func (a *App) GetBuildLog(buildID string) (res string) {
+	res, err := a.deps.Services.CentralService.GetBuildLog(buildID)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+// RunTests запускает тесты приложения (integration - вместе с интеграционными); отмена - CancelBuild.
+func (a *App) RunTests(appName string, integration bool) (res *domain.TestRunResult) {
+	res, err := a.deps.Services.CentralService.RunTests(appName, integration)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) GetTestResult(appName string) *domain.TestRunResult {
+	return a.deps.Services.CentralService.GetTestResult(appName)
+}
+
+func (a *App) GetJarInfo(appName string) (res *domain.JarInfo) {
+	res, err := a.deps.Services.CentralService.GetJarInfo(appName)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+// InspectJar читает метаданные произвольного jar (например, выбранного в диалоге до сохранения).
+func (a *App) InspectJar(jarPath string) (res *domain.JarInfo) {
+	res, err := util.ReadJarInfo(jarPath)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+func (a *App) GetJarStatus(appName string) (res *domain.JarStatus) {
+	res, err := a.deps.Services.CentralService.GetJarStatus(appName)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+// RebuildAndRestart собирает приложение и перезапускает его только после успешной сборки.
+func (a *App) RebuildAndRestart(appName string) (res *dto.RebuildResultDTO) {
+	res, err := a.deps.Services.CentralService.RebuildAndRestart(appName)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+// RebuildAndRestartAll - пересборка и перезапуск стека (пустой appNames — все активные приложения).
+func (a *App) RebuildAndRestartAll(appNames []string) (res []dto.RebuildResultDTO) {
+	res, err := a.deps.Services.CentralService.RebuildAndRestartAll(appNames)
+	if err != nil {
+		a.logError(err)
+	}
+	return
+}
+
+// CancelGitOperation прерывает git команды (fetch, pull, checkout, ...) в репозитории приложения.
+func (a *App) CancelGitOperation(appName string) {
+	_, err := a.deps.Services.CentralService.CancelGitOperation(appName)
+	if err != nil {
+		a.logError(err)
+	}
+}
+
+func (a *App) CancelAllGitOperations() {
+	a.deps.Services.CentralService.CancelAllGitOperations()
+}
+
 func initDeps(ctx context.Context, logger *slog.Logger) *app.Deps {
 
 	services := initServices(logger, ctx)
 
 	return &app.Deps{
-		Services:  services,
-		Logger:    logger,
-		LogTailer: util.NewLogTailer(),
+		Services:        services,
+		Logger:          logger,
+		LogTailer:       util.NewLogTailer(),
+		MergedLogTailer: util.NewMergedLogTailer(),
 	}
 }
 
 func initServices(logger *slog.Logger, ctx context.Context) *service.Services {
 	settingsService := service.NewSettingsService(logger, ctx)
-	gitService := service.NewGitService(logger, ctx)
+	gitService := service.NewGitService(logger, settingsService, ctx)
+	alertService := service.NewAlertService(logger, ctx)
+	diagnosticsService := service.NewDiagnosticsService(logger, gitService, ctx)
+	gitStatusMonitor := service.NewGitStatusMonitor(logger, gitService, ctx)
+	buildService := service.NewBuildService(logger, settingsService, ctx)
+	jarStatusChecker := service.NewJarStatusChecker(logger, gitService, ctx)
+	jarScanService := service.NewJarScanService(logger, settingsService, ctx)
 
 	return &service.Services{
-		CentralService:  service.NewCentralService(logger, settingsService, gitService, ctx),
-		SettingsService: settingsService,
-		GitService:      gitService,
+		CentralService: service.NewCentralService(logger, settingsService, gitService, alertService, diagnosticsService,
+			gitStatusMonitor, buildService, jarStatusChecker, ctx),
+		SettingsService:    settingsService,
+		GitService:         gitService,
+		AlertService:       alertService,
+		DiagnosticsService: diagnosticsService,
+		GitStatusMonitor:   gitStatusMonitor,
+		BuildService:       buildService,
+		JarStatusChecker:   jarStatusChecker,
+		JarScanService:     jarScanService,
 	}
 }
@@ -6,6 +6,7 @@ require (
 	github.com/lutischan-ferenc/systray v1.3.0
 	github.com/wailsapp/wails/v2 v2.11.0
 	golang.org/x/sys v0.30.0
+	golang.org/x/text v0.22.0
 )
 
 require (
@@ -36,5 +37,4 @@ require (
 	github.com/wailsapp/mimetype v1.4.1 // indirect
 	golang.org/x/crypto v0.33.0 // indirect
 	golang.org/x/net v0.35.0 // indirect
-	golang.org/x/text v0.22.0 // indirect
 )
@@ -7,7 +7,8 @@ import (
 )
 
 type Deps struct {
-	Services  *service.Services
-	LogTailer *util.LogTailer
-	Logger    *slog.Logger
+	Services        *service.Services
+	LogTailer       *util.LogTailer
+	MergedLogTailer *util.MergedLogTailer
+	Logger          *slog.Logger
 }
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+type AlertSeverity string
+
+const (
+	AlertSeverityInfo  AlertSeverity = "info"
+	AlertSeverityWarn  AlertSeverity = "warn"
+	AlertSeverityError AlertSeverity = "error"
+)
+
+type AlertRule struct {
+	Name        string        `json:"name"`
+	Pattern     string        `json:"pattern"`
+	Severity    AlertSeverity `json:"severity"`
+	CooldownSec uint          `json:"cooldownSec"`
+	IsActive    bool          `json:"isActive"`
+}
+
+type LogAlert struct {
+	AppName  string        `json:"appName"`
+	RuleName string        `json:"ruleName"`
+	Severity AlertSeverity `json:"severity"`
+	Line     string        `json:"line"`
+	RaisedAt time.Time     `json:"raisedAt"`
+}
@@ -1,8 +1,16 @@
 package domain
 
 type AppSettings struct {
-	CentralInfoPath             string `json:"centralInfoPath"`
-	ApplicationStartingDelaySec uint   `json:"applicationStartingDelaySec"`
-	MinimizeToTrayOnClose       bool   `json:"minimizeToTrayOnClose"`
-	StartQuietMode              bool   `json:"startQuietMode"`
+	CentralInfoPath             string   `json:"centralInfoPath"`
+	ApplicationStartingDelaySec uint     `json:"applicationStartingDelaySec"`
+	MinimizeToTrayOnClose       bool     `json:"minimizeToTrayOnClose"`
+	StartQuietMode              bool     `json:"startQuietMode"`
+	TraceIDPattern              string   `json:"traceIdPattern"`
+	GitStatusRefreshSec         uint     `json:"gitStatusRefreshSec"`
+	GitMaxParallel              uint     `json:"gitMaxParallel"`
+	GitTimeoutSec               uint     `json:"gitTimeoutSec"`
+	BuildMaxParallel            uint     `json:"buildMaxParallel"`
+	ReadinessTimeoutSec         uint     `json:"readinessTimeoutSec"`
+	DefaultGitBranch            string   `json:"defaultGitBranch"`
+	JarScanIgnore               []string `json:"jarScanIgnore"`
 }
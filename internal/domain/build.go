@@ -0,0 +1,92 @@
+package domain
+
+import "time"
+
+type BuildTool string
+
+const (
+	BuildToolAuto   BuildTool = ""
+	BuildToolMaven  BuildTool = "maven"
+	BuildToolGradle BuildTool = "gradle"
+)
+
+// BuildOptions - параметры сборки, независимые от JVM опций запуска приложения:
+// - Tool - maven или gradle (пусто — по наличию pom.xml, затем build.gradle)
+// - MavenGoals - цели Maven (пусто — "package -DskipTests")
+// - GradleTasks - задачи Gradle (пусто — "bootJar")
+// - Profiles - профили Maven (-P)
+// - Properties - свойства сборки (-D для Maven, -P для Gradle), учитываются только активные
+// - SettingsFile - settings.xml Maven (-s)
+// - LocalRepository - локальный репозиторий Maven (-Dmaven.repo.local)
+// - Offline - без обращения к удалённым репозиториям (-o / --offline)
+// - JavaHome - JDK, которым выполняется сборка (JAVA_HOME процесса сборки)
+type BuildOptions struct {
+	Tool            BuildTool     `json:"tool"`
+	MavenGoals      string        `json:"mavenGoals"`
+	GradleTasks     string        `json:"gradleTasks"`
+	Profiles        []string      `json:"profiles"`
+	Properties      []EnvVariable `json:"properties"`
+	SettingsFile    string        `json:"settingsFile"`
+	LocalRepository string        `json:"localRepository"`
+	Offline         bool          `json:"offline"`
+	JavaHome        string        `json:"javaHome"`
+}
+
+type BuildStatus string
+
+const (
+	BuildStatusQueued   BuildStatus = "queued"
+	BuildStatusRunning  BuildStatus = "running"
+	BuildStatusSuccess  BuildStatus = "success"
+	BuildStatusFailed   BuildStatus = "failed"
+	BuildStatusCanceled BuildStatus = "canceled"
+)
+
+// BuildResult - JarProduced: после сборки JarPath существует и обновлён; LogPath: полный вывод сборки
+// (пусто, если сборка отменена ещё в очереди).
+type BuildResult struct {
+	ID          string      `json:"id"`
+	AppName     string      `json:"appName"`
+	Tool        string      `json:"tool"`
+	Command     []string    `json:"command"`
+	Status      BuildStatus `json:"status"`
+	QueuedAt    time.Time   `json:"queuedAt"`
+	StartedAt   time.Time   `json:"startedAt"`
+	FinishedAt  time.Time   `json:"finishedAt"`
+	DurationMs  int64       `json:"durationMs"`
+	ExitCode    int         `json:"exitCode"`
+	JarPath     string      `json:"jarPath"`
+	JarProduced bool        `json:"jarProduced"`
+	LogPath     string      `json:"logPath"`
+	Error       string      `json:"error"`
+}
+
+type BuildJobKind string
+
+const (
+	BuildJobKindBuild BuildJobKind = "build"
+	BuildJobKindTest  BuildJobKind = "test"
+)
+
+// BuildQueueItem - задание в очереди сборок. Position: 0 - выполняется, 1.. - место среди ожидающих.
+type BuildQueueItem struct {
+	AppName   string       `json:"appName"`
+	Kind      BuildJobKind `json:"kind"`
+	Position  int          `json:"position"`
+	QueuedAt  time.Time    `json:"queuedAt"`
+	StartedAt time.Time    `json:"startedAt"`
+}
+
+type BuildOutput struct {
+	AppName string   `json:"appName"`
+	Lines   []string `json:"lines"`
+}
+
+// JarStatus - актуальность jar относительно исходников. JarCommit - коммит из git.properties внутри jar.
+type JarStatus struct {
+	Outdated  bool      `json:"outdated"`
+	Reason    string    `json:"reason"`
+	JarCommit string    `json:"jarCommit"`
+	BuiltAt   time.Time `json:"builtAt"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
@@ -2,6 +2,7 @@ package domain
 
 type CentralInfo struct {
 	GlobalVariables  []EnvVariable     `json:"globalVariables"`
+	GlobalAlertRules []AlertRule       `json:"globalAlertRules"`
 	ApplicationInfos []ApplicationInfo `json:"applicationInfos"`
 }
 
@@ -11,14 +12,46 @@ type EnvVariable struct {
 	IsActive bool   `json:"isActive"`
 }
 
+// ApplicationInfo:
+// - JarPattern - шаблон jar относительно BaseDir (например target/foo-*.jar); при запуске JarPath
+// заменяется самым новым подходящим исполняемым jar (пусто — JarPath фиксирован)
+// - GitRoot - корень Git репозитория, в котором лежит BaseDir (BaseDir может быть подпапкой модуля)
+// - BuildOptions - инструмент, цели/задачи, профили, свойства, settings.xml и JDK для сборки и тестов
+// - LegacyBuildGoals - buildGoals из старого central-info.json, при загрузке переносится в BuildOptions
+// - WorktreeOf - имя приложения, из репозитория которого создан git worktree (пусто у обычных приложений)
+// - ServerPort - порт, передаваемый как -Dserver.port (0 — не задавать)
+// - RebuildBeforeRun - перед запуском пересобирать приложение, если jar устарел
 type ApplicationInfo struct {
-	AppName      string        `json:"appName"`
-	EnvVariables []EnvVariable `json:"envVariables"`
-	AppArguments []string      `json:"appArguments"`
-	BaseDir      string        `json:"baseDir"`
-	JarPath      string        `json:"jarPath"`
-	StartOrder   uint8         `json:"startOrder"`
-	IsActive     bool          `json:"isActive"`
-	HasGit       bool          `json:"hasGit"`
-	HasMaven     bool          `json:"hasMaven"`
+	AppName          string         `json:"appName"`
+	EnvVariables     []EnvVariable  `json:"envVariables"`
+	AppArguments     []string       `json:"appArguments"`
+	BaseDir          string         `json:"baseDir"`
+	JarPath          string         `json:"jarPath"`
+	JarPattern       string         `json:"jarPattern"`
+	StartOrder       uint8          `json:"startOrder"`
+	IsActive         bool           `json:"isActive"`
+	HasGit           bool           `json:"hasGit"`
+	GitRoot          string         `json:"gitRoot"`
+	HasMaven         bool           `json:"hasMaven"`
+	HasGradle        bool           `json:"hasGradle"`
+	LegacyBuildGoals string         `json:"buildGoals,omitempty"`
+	BuildOptions     BuildOptions   `json:"buildOptions"`
+	AlertRules       []AlertRule    `json:"alertRules"`
+	OutputEncoding   OutputEncoding `json:"outputEncoding"`
+	PassEncodingArgs bool           `json:"passEncodingArgs"`
+	DefaultBranch    string         `json:"defaultBranch"`
+	WorktreeOf       string         `json:"worktreeOf"`
+	ServerPort       int            `json:"serverPort"`
+	RebuildBeforeRun bool           `json:"rebuildBeforeRun"`
 }
+
+type OutputEncoding string
+
+const (
+	// OutputEncodingDefault - поведение по умолчанию: chcp 1251 в консоли и автоопределение в логе
+	OutputEncodingDefault     OutputEncoding = ""
+	OutputEncodingAuto        OutputEncoding = "auto"
+	OutputEncodingUTF8        OutputEncoding = "utf-8"
+	OutputEncodingWindows1251 OutputEncoding = "windows-1251"
+	OutputEncodingCP866       OutputEncoding = "cp866"
+)
@@ -1,7 +1,124 @@
 package domain
 
+import "time"
+
 type Branches struct {
 	Current string
 	Local   []string
 	Remote  []string
+	Remotes []string
+}
+
+type GitCommit struct {
+	Hash        string    `json:"hash"`
+	ShortHash   string    `json:"shortHash"`
+	Author      string    `json:"author"`
+	AuthorEmail string    `json:"authorEmail"`
+	Date        time.Time `json:"date"`
+	Message     string    `json:"message"`
+}
+
+type RepoStatus struct {
+	Branch     string     `json:"branch"`
+	Detached   bool       `json:"detached"`
+	DetachedAt string     `json:"detachedAt"`
+	Upstream   string     `json:"upstream"`
+	Ahead      int        `json:"ahead"`
+	Behind     int        `json:"behind"`
+	Staged     []string   `json:"staged"`
+	Unstaged   []string   `json:"unstaged"`
+	Untracked  []string   `json:"untracked"`
+	Head       *GitCommit `json:"head"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+func (s *RepoStatus) IsClean() bool {
+	return len(s.Staged) == 0 && len(s.Unstaged) == 0 && len(s.Untracked) == 0
+}
+
+type PullResult struct {
+	Branch     string `json:"branch"`
+	Upstream   string `json:"upstream"`
+	FromCommit string `json:"fromCommit"`
+	ToCommit   string `json:"toCommit"`
+	Range      string `json:"range"`
+	Commits    int    `json:"commits"`
+	UpToDate   bool   `json:"upToDate"`
+	Rebased    bool   `json:"rebased"`
+}
+
+type StashEntry struct {
+	Ref     string    `json:"ref"`
+	Hash    string    `json:"hash"`
+	Message string    `json:"message"`
+	Date    time.Time `json:"date"`
+}
+
+type CheckoutOptions struct {
+	// Remote - remote, ветку которого отслеживать, если ветка есть на нескольких remote
+	Remote    string `json:"remote"`
+	AutoStash bool   `json:"autoStash"`
+}
+
+type CheckoutResult struct {
+	Branch           string   `json:"branch"`
+	TrackingRef      string   `json:"trackingRef"`
+	CandidateRemotes []string `json:"candidateRemotes"`
+	Stashed          bool     `json:"stashed"`
+	StashHash        string   `json:"stashHash"`
+	Restored         bool     `json:"restored"`
+	Conflicts        []string `json:"conflicts"`
+	SharedWith       []string `json:"sharedWith"`
+}
+
+type CommitPage struct {
+	Commits []GitCommit `json:"commits"`
+	HasMore bool        `json:"hasMore"`
+}
+
+type ChangedFile struct {
+	Path      string `json:"path"`
+	OldPath   string `json:"oldPath"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Binary    bool   `json:"binary"`
+}
+
+type CommitDetails struct {
+	Commit GitCommit     `json:"commit"`
+	Body   string        `json:"body"`
+	Files  []ChangedFile `json:"files"`
+}
+
+// CreateBranchOptions - StartRef: ветка, тег или коммит, от которого создаётся ветка; пусто — HEAD.
+type CreateBranchOptions struct {
+	Name     string `json:"name"`
+	StartRef string `json:"startRef"`
+	Checkout bool   `json:"checkout"`
+	Push     bool   `json:"push"`
+	Remote   string `json:"remote"`
+}
+
+type PruneResult struct {
+	Deleted []string `json:"deleted"`
+	Skipped []string `json:"skipped"`
+}
+
+type Worktree struct {
+	Path     string `json:"path"`
+	Branch   string `json:"branch"`
+	Head     string `json:"head"`
+	Detached bool   `json:"detached"`
+	Main     bool   `json:"main"`
+	Locked   bool   `json:"locked"`
+	Prunable bool   `json:"prunable"`
+}
+
+// GitProgress - строка вывода долгой git операции; Percent = -1, если процента в строке нет.
+type GitProgress struct {
+	GitPath   string `json:"gitPath"`
+	Operation string `json:"operation"`
+	Message   string `json:"message"`
+	Percent   int    `json:"percent"`
 }
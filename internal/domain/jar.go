@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// JarInfo - метаданные jar из MANIFEST.MF, build-info.properties и git.properties.
+// JavaVersion - минимальная версия Java по версии class-файла главного класса (0 — не удалось определить).
+type JarInfo struct {
+	JarPath           string    `json:"jarPath"`
+	Size              int64     `json:"size"`
+	ModifiedAt        time.Time `json:"modifiedAt"`
+	MainClass         string    `json:"mainClass"`
+	StartClass        string    `json:"startClass"`
+	Executable        bool      `json:"executable"`
+	SpringBootVersion string    `json:"springBootVersion"`
+	Group             string    `json:"group"`
+	Artifact          string    `json:"artifact"`
+	Name              string    `json:"name"`
+	Version           string    `json:"version"`
+	BuildTime         time.Time `json:"buildTime"`
+	CreatedBy         string    `json:"createdBy"`
+	BuildJdk          string    `json:"buildJdk"`
+	JavaVersion       int       `json:"javaVersion"`
+	GitCommit         string    `json:"gitCommit"`
+	GitBranch         string    `json:"gitBranch"`
+	GitCommitTime     string    `json:"gitCommitTime"`
+	GitDirty          bool      `json:"gitDirty"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// JarCandidate - найденный при сканировании jar. BuildOutput: лежит прямо в target/ или build/libs/.
+type JarCandidate struct {
+	Path        string    `json:"path"`
+	MainClass   string    `json:"mainClass"`
+	Executable  bool      `json:"executable"`
+	BuildOutput bool      `json:"buildOutput"`
+	Size        int64     `json:"size"`
+	ModifiedAt  time.Time `json:"modifiedAt"`
+}
+
+type JarScanProgress struct {
+	BaseDir    string `json:"baseDir"`
+	Dirs       int    `json:"dirs"`
+	Jars       int    `json:"jars"`
+	CurrentDir string `json:"currentDir"`
+	Done       bool   `json:"done"`
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+type TestRunStatus string
+
+const (
+	TestRunStatusRunning  TestRunStatus = "running"
+	TestRunStatusPassed   TestRunStatus = "passed"
+	TestRunStatusFailed   TestRunStatus = "failed"
+	TestRunStatusError    TestRunStatus = "error"
+	TestRunStatusCanceled TestRunStatus = "canceled"
+)
+
+// TestRunResult - Status error: инструмент завершился с ошибкой, но отчётов о падениях тестов нет
+// (ошибка компиляции, конфигурации и т.п.). Reports - разобранные файлы JUnit XML.
+type TestRunResult struct {
+	AppName     string        `json:"appName"`
+	Tool        string        `json:"tool"`
+	Integration bool          `json:"integration"`
+	Command     []string      `json:"command"`
+	Status      TestRunStatus `json:"status"`
+	StartedAt   time.Time     `json:"startedAt"`
+	FinishedAt  time.Time     `json:"finishedAt"`
+	DurationMs  int64         `json:"durationMs"`
+	ExitCode    int           `json:"exitCode"`
+	Summary     TestSummary   `json:"summary"`
+	Failures    []TestFailure `json:"failures"`
+	Reports     []string      `json:"reports"`
+	LogPath     string        `json:"logPath"`
+	Error       string        `json:"error"`
+}
+
+type TestSummary struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Errors  int `json:"errors"`
+	Skipped int `json:"skipped"`
+}
+
+// TestFailure - упавший тест. Kind: failure (не прошла проверка) или error (исключение в тесте).
+type TestFailure struct {
+	Suite   string `json:"suite"`
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Details string `json:"details"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "central-desktop/internal/domain"
+
+// RebuildStatus - этап пересборки; RebuildBuilt - собрано, но не перезапускалось, так как не было запущено.
+type RebuildStatus string
+
+const (
+	RebuildBuilding    RebuildStatus = "building"
+	RebuildBuilt       RebuildStatus = "built"
+	RebuildRestarting  RebuildStatus = "restarting"
+	RebuildRestarted   RebuildStatus = "restarted"
+	RebuildBuildFailed RebuildStatus = "buildFailed"
+	RebuildStartFailed RebuildStatus = "startFailed"
+	RebuildSkipped     RebuildStatus = "skipped"
+)
+
+// RebuildResultDTO - Ready: после запуска приложение прошло проверку готовности.
+type RebuildResultDTO struct {
+	AppName string              `json:"appName"`
+	Status  RebuildStatus       `json:"status"`
+	Build   *domain.BuildResult `json:"build"`
+	Ready   bool                `json:"ready"`
+	Error   string              `json:"error"`
+}
@@ -1,7 +1,10 @@
 package dto
 
+import "central-desktop/internal/domain"
+
 type CentralInfoDTO struct {
 	GlobalVariables  []EnvVariableDTO     `json:"globalVariables"`
+	GlobalAlertRules []AlertRuleDTO       `json:"globalAlertRules"`
 	ApplicationInfos []ApplicationInfoDTO `json:"applicationInfos"`
 }
 
@@ -11,17 +14,55 @@ type EnvVariableDTO struct {
 	IsActive bool   `json:"isActive"`
 }
 
+// ApplicationInfoDTO - ResolvedJarPath: jar, который будет запущен (найденный по JarPattern, если он задан).
 type ApplicationInfoDTO struct {
-	AppName      string           `json:"appName"`
-	EnvVariables []EnvVariableDTO `json:"envVariables"`
-	AppArguments []string         `json:"appArguments"`
-	BaseDir      string           `json:"baseDir"`
-	JarPath      string           `json:"jarPath"`
-	StartOrder   uint8            `json:"startOrder"`
-	IsActive     bool             `json:"isActive"`
-	PID          int              `json:"pid"`
-	HasGit       bool             `json:"hasGit"`
-	HasMaven     bool             `json:"hasMaven"`
+	AppName          string                `json:"appName"`
+	EnvVariables     []EnvVariableDTO      `json:"envVariables"`
+	AppArguments     []string              `json:"appArguments"`
+	BaseDir          string                `json:"baseDir"`
+	JarPath          string                `json:"jarPath"`
+	JarPattern       string                `json:"jarPattern"`
+	ResolvedJarPath  string                `json:"resolvedJarPath"`
+	StartOrder       uint8                 `json:"startOrder"`
+	IsActive         bool                  `json:"isActive"`
+	PID              int                   `json:"pid"`
+	HasGit           bool                  `json:"hasGit"`
+	GitRoot          string                `json:"gitRoot"`
+	SharedRepoWith   []string              `json:"sharedRepoWith"`
+	HasMaven         bool                  `json:"hasMaven"`
+	HasGradle        bool                  `json:"hasGradle"`
+	BuildOptions     BuildOptionsDTO       `json:"buildOptions"`
+	LastBuild        *domain.BuildResult   `json:"lastBuild"`
+	LastTestRun      *domain.TestRunResult `json:"lastTestRun"`
+	JarStatus        *domain.JarStatus     `json:"jarStatus"`
+	RebuildBeforeRun bool                  `json:"rebuildBeforeRun"`
+	AlertRules       []AlertRuleDTO        `json:"alertRules"`
+	OutputEncoding   string                `json:"outputEncoding"`
+	PassEncodingArgs bool                  `json:"passEncodingArgs"`
+	GitStatus        *domain.RepoStatus    `json:"gitStatus"`
+	DefaultBranch    string                `json:"defaultBranch"`
+	WorktreeOf       string                `json:"worktreeOf"`
+	ServerPort       int                   `json:"serverPort"`
+}
+
+type BuildOptionsDTO struct {
+	Tool            string           `json:"tool"`
+	MavenGoals      string           `json:"mavenGoals"`
+	GradleTasks     string           `json:"gradleTasks"`
+	Profiles        []string         `json:"profiles"`
+	Properties      []EnvVariableDTO `json:"properties"`
+	SettingsFile    string           `json:"settingsFile"`
+	LocalRepository string           `json:"localRepository"`
+	Offline         bool             `json:"offline"`
+	JavaHome        string           `json:"javaHome"`
+}
+
+type AlertRuleDTO struct {
+	Name        string `json:"name"`
+	Pattern     string `json:"pattern"`
+	Severity    string `json:"severity"`
+	CooldownSec uint   `json:"cooldownSec"`
+	IsActive    bool   `json:"isActive"`
 }
 
 type RunningProcessDTO struct {
@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+type DiagnosticsManifestDTO struct {
+	CreatedAt    time.Time           `json:"createdAt"`
+	JavaVersion  string              `json:"javaVersion"`
+	GlobalEnv    []string            `json:"globalEnv"`
+	Applications []DiagnosticsAppDTO `json:"applications"`
+	Files        []string            `json:"files"`
+	Errors       []string            `json:"errors"`
+}
+
+type DiagnosticsAppDTO struct {
+	AppName     string   `json:"appName"`
+	BaseDir     string   `json:"baseDir"`
+	JarPath     string   `json:"jarPath"`
+	CommandLine []string `json:"commandLine"`
+	Env         []string `json:"env"`
+	GitBranch   string   `json:"gitBranch"`
+	GitCommit   string   `json:"gitCommit"`
+}
@@ -0,0 +1,50 @@
+package dto
+
+import "central-desktop/internal/domain"
+
+type RepoUpdateResultDTO struct {
+	AppNames []string           `json:"appNames"`
+	GitPath  string             `json:"gitPath"`
+	Result   *domain.PullResult `json:"result"`
+	Error    string             `json:"error"`
+}
+
+// FeatureBranchRequestDTO - пустой AppNames означает все приложения с Git.
+// Rebuild: перед перезапуском приложение пересобирается (включает Restart).
+type FeatureBranchRequestDTO struct {
+	Branch    string   `json:"branch"`
+	AppNames  []string `json:"appNames"`
+	Fetch     bool     `json:"fetch"`
+	AutoStash bool     `json:"autoStash"`
+	Restart   bool     `json:"restart"`
+	Rebuild   bool     `json:"rebuild"`
+}
+
+type FeatureBranchStatus string
+
+const (
+	FeatureBranchSwitched FeatureBranchStatus = "switched"
+	FeatureBranchFallback FeatureBranchStatus = "fallback"
+	FeatureBranchSkipped  FeatureBranchStatus = "skipped"
+	FeatureBranchFailed   FeatureBranchStatus = "failed"
+)
+
+// FeatureBranchResultDTO - Rebuild заполняется, если приложение пересобиралось перед перезапуском.
+type FeatureBranchResultDTO struct {
+	AppName   string              `json:"appName"`
+	Branch    string              `json:"branch"`
+	Status    FeatureBranchStatus `json:"status"`
+	Restarted bool                `json:"restarted"`
+	Rebuild   *RebuildResultDTO   `json:"rebuild"`
+	Error     string              `json:"error"`
+}
+
+// WorktreeRequestDTO - Path и AppName можно не указывать: по умолчанию worktree создаётся
+// в <корень репозитория>-worktrees/<ветка>, а приложение называется "<AppName> (<ветка>)".
+type WorktreeRequestDTO struct {
+	AppName    string `json:"appName"`
+	Branch     string `json:"branch"`
+	Path       string `json:"path"`
+	NewAppName string `json:"newAppName"`
+	ServerPort int    `json:"serverPort"`
+}
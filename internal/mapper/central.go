@@ -22,6 +22,7 @@ func ToCentralInfoDTO(ci *domain.CentralInfo) dto.CentralInfoDTO {
 
 	return dto.CentralInfoDTO{
 		GlobalVariables:  evDTOs,
+		GlobalAlertRules: ToAlertRuleDTOs(ci.GlobalAlertRules),
 		ApplicationInfos: aiDTOs,
 	}
 }
@@ -37,15 +38,26 @@ func ToApplicationInfoDTO(ai *domain.ApplicationInfo) dto.ApplicationInfoDTO {
 	}
 
 	return dto.ApplicationInfoDTO{
-		AppName:      ai.AppName,
-		EnvVariables: evDTOs,
-		AppArguments: ai.AppArguments,
-		BaseDir:      ai.BaseDir,
-		JarPath:      ai.JarPath,
-		StartOrder:   ai.StartOrder,
-		IsActive:     ai.IsActive,
-		HasGit:       ai.HasGit,
-		HasMaven:     ai.HasMaven,
+		AppName:          ai.AppName,
+		EnvVariables:     evDTOs,
+		AppArguments:     ai.AppArguments,
+		BaseDir:          ai.BaseDir,
+		JarPath:          ai.JarPath,
+		JarPattern:       ai.JarPattern,
+		StartOrder:       ai.StartOrder,
+		IsActive:         ai.IsActive,
+		HasGit:           ai.HasGit,
+		GitRoot:          ai.GitRoot,
+		HasMaven:         ai.HasMaven,
+		HasGradle:        ai.HasGradle,
+		BuildOptions:     ToBuildOptionsDTO(&ai.BuildOptions),
+		AlertRules:       ToAlertRuleDTOs(ai.AlertRules),
+		OutputEncoding:   string(ai.OutputEncoding),
+		PassEncodingArgs: ai.PassEncodingArgs,
+		DefaultBranch:    ai.DefaultBranch,
+		WorktreeOf:       ai.WorktreeOf,
+		ServerPort:       ai.ServerPort,
+		RebuildBeforeRun: ai.RebuildBeforeRun,
 	}
 }
 
@@ -59,3 +71,35 @@ func ToEnvVariableDTO(ev *domain.EnvVariable) dto.EnvVariableDTO {
 		IsActive: ev.IsActive,
 	}
 }
+
+func ToBuildOptionsDTO(opts *domain.BuildOptions) dto.BuildOptionsDTO {
+	props := make([]dto.EnvVariableDTO, len(opts.Properties))
+	for i := range opts.Properties {
+		props[i] = ToEnvVariableDTO(&opts.Properties[i])
+	}
+	return dto.BuildOptionsDTO{
+		Tool:            string(opts.Tool),
+		MavenGoals:      opts.MavenGoals,
+		GradleTasks:     opts.GradleTasks,
+		Profiles:        opts.Profiles,
+		Properties:      props,
+		SettingsFile:    opts.SettingsFile,
+		LocalRepository: opts.LocalRepository,
+		Offline:         opts.Offline,
+		JavaHome:        opts.JavaHome,
+	}
+}
+
+func ToAlertRuleDTOs(rules []domain.AlertRule) []dto.AlertRuleDTO {
+	res := make([]dto.AlertRuleDTO, len(rules))
+	for i := range rules {
+		res[i] = dto.AlertRuleDTO{
+			Name:        rules[i].Name,
+			Pattern:     rules[i].Pattern,
+			Severity:    string(rules[i].Severity),
+			CooldownSec: rules[i].CooldownSec,
+			IsActive:    rules[i].IsActive,
+		}
+	}
+	return res
+}
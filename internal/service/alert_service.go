@@ -0,0 +1,210 @@
+package service
+
+import (
+	"central-desktop/internal/domain"
+	"central-desktop/internal/util"
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	maxAlertHistory     = 500
+	maxAlertLineLength  = 300
+	alertRaisedEventKey = "alert:raised"
+)
+
+type AlertService struct {
+	logger     *slog.Logger
+	ctx        context.Context
+	watcher    *util.LogWatcher
+	mu         sync.Mutex
+	history    []domain.LogAlert
+	lastRaised map[string]time.Time
+	listeners  []func(domain.LogAlert)
+}
+
+type compiledAlertRule struct {
+	rule domain.AlertRule
+	re   *regexp.Regexp
+}
+
+func NewAlertService(lg *slog.Logger, ctx context.Context) *AlertService {
+	lg.Info("Initializing alert service")
+	return &AlertService{
+		logger:     lg,
+		ctx:        ctx,
+		watcher:    util.NewLogWatcher(),
+		lastRaised: make(map[string]time.Time),
+	}
+}
+
+// Watch (пере)запускает фоновое слежение за логами всех приложений из ci
+// с глобальными правилами и правилами конкретного приложения.
+func (s *AlertService) Watch(ci *domain.CentralInfo) error {
+	globalRules, err := compileAlertRules(ci.GlobalAlertRules)
+	if err != nil {
+		return err
+	}
+
+	logsDir, err := util.LogsDir()
+	if err != nil {
+		return err
+	}
+
+	rulesByApp := make(map[string][]compiledAlertRule, len(ci.ApplicationInfos))
+	sources := make([]util.LogSource, 0, len(ci.ApplicationInfos))
+	for _, ai := range ci.ApplicationInfos {
+		appRules, err := compileAlertRules(ai.AlertRules)
+		if err != nil {
+			return fmt.Errorf("%s: %w", ai.AppName, err)
+		}
+
+		rules := append(appRules, globalRules...)
+		if len(rules) == 0 {
+			continue
+		}
+
+		rulesByApp[ai.AppName] = rules
+		sources = append(sources, util.LogSource{
+			AppName:  ai.AppName,
+			Path:     filepath.Join(logsDir, util.GetLogFileName(ai.AppName)),
+			Encoding: ai.OutputEncoding,
+		})
+	}
+
+	s.watcher.Watch(s.ctx, sources, func(src util.LogSource, lines []string) {
+		rules := rulesByApp[src.AppName]
+		for _, line := range lines {
+			for _, r := range rules {
+				if r.re.MatchString(line) {
+					s.raise(src.AppName, r.rule, line)
+				}
+			}
+		}
+	})
+
+	s.logger.Info("alert watcher started", "apps", len(sources))
+	return nil
+}
+
+func (s *AlertService) Stop() {
+	s.watcher.Stop()
+}
+
+// OnAlert регистрирует обработчик, который вызывается при каждом сработавшем правиле.
+func (s *AlertService) OnAlert(fn func(domain.LogAlert)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+// GetHistory возвращает историю оповещений, новые — первыми.
+func (s *AlertService) GetHistory() []domain.LogAlert {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]domain.LogAlert, len(s.history))
+	for i := range s.history {
+		res[i] = s.history[len(s.history)-1-i]
+	}
+	return res
+}
+
+func (s *AlertService) ClearHistory() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = nil
+}
+
+func (s *AlertService) raise(appName string, rule domain.AlertRule, line string) {
+	now := time.Now()
+	key := appName + "\x00" + rule.Name + "\x00" + rule.Pattern
+
+	s.mu.Lock()
+	if last, ok := s.lastRaised[key]; ok && now.Sub(last) < time.Duration(rule.CooldownSec)*time.Second {
+		s.mu.Unlock()
+		return
+	}
+	s.lastRaised[key] = now
+
+	line = strings.TrimSpace(line)
+	if r := []rune(line); len(r) > maxAlertLineLength {
+		line = string(r[:maxAlertLineLength]) + "..."
+	}
+
+	alert := domain.LogAlert{
+		AppName:  appName,
+		RuleName: rule.Name,
+		Severity: rule.Severity,
+		Line:     line,
+		RaisedAt: now,
+	}
+
+	s.history = append(s.history, alert)
+	if len(s.history) > maxAlertHistory {
+		s.history = s.history[len(s.history)-maxAlertHistory:]
+	}
+	listeners := append([]func(domain.LogAlert){}, s.listeners...)
+	s.mu.Unlock()
+
+	s.logger.Warn("log alert raised", "app", appName, "rule", rule.Name, "line", line)
+
+	title := fmt.Sprintf("%s: %s", appName, rule.Name)
+	switch rule.Severity {
+	case domain.AlertSeverityError:
+		util.NotifyError(s.ctx, title, line)
+	case domain.AlertSeverityWarn:
+		util.NotifyWarn(s.ctx, title, line)
+	default:
+		util.NotifyInfo(s.ctx, title, line)
+	}
+	runtime.EventsEmit(s.ctx, alertRaisedEventKey, alert)
+
+	for _, fn := range listeners {
+		fn(alert)
+	}
+}
+
+// ValidateAlertRules проверяет правила перед сохранением.
+func ValidateAlertRules(rules []domain.AlertRule) error {
+	for _, r := range rules {
+		if strings.TrimSpace(r.Name) == "" {
+			return fmt.Errorf("не указано имя правила оповещения")
+		}
+		if strings.TrimSpace(r.Pattern) == "" {
+			return fmt.Errorf("не указано регулярное выражение для правила %s", r.Name)
+		}
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("некорректное регулярное выражение в правиле %s: %w", r.Name, err)
+		}
+		switch r.Severity {
+		case "", domain.AlertSeverityInfo, domain.AlertSeverityWarn, domain.AlertSeverityError:
+		default:
+			return fmt.Errorf("неизвестный уровень оповещения %q в правиле %s", r.Severity, r.Name)
+		}
+	}
+	return nil
+}
+
+func compileAlertRules(rules []domain.AlertRule) ([]compiledAlertRule, error) {
+	res := make([]compiledAlertRule, 0, len(rules))
+	for _, r := range rules {
+		if !r.IsActive {
+			continue
+		}
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("alert rule %s: %w", r.Name, err)
+		}
+		res = append(res, compiledAlertRule{rule: r, re: re})
+	}
+	return res, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"central-desktop/internal/domain"
+	"context"
+	"sync"
+	"time"
+)
+
+// buildJob - задание в очереди; ready закрывается, когда заданию выделен слот.
+type buildJob struct {
+	appName   string
+	kind      domain.BuildJobKind
+	queuedAt  time.Time
+	startedAt time.Time
+	ready     chan struct{}
+}
+
+// buildQueue выполняет задания в порядке поступления, не больше limit() одновременно.
+// Лимит читается при каждом освобождении слота, поэтому изменение настроек применяется сразу.
+type buildQueue struct {
+	mu       sync.Mutex
+	jobs     []*buildJob
+	active   int
+	limit    func() int
+	onChange func(items []domain.BuildQueueItem)
+}
+
+func newBuildQueue(limit func() int, onChange func(items []domain.BuildQueueItem)) *buildQueue {
+	return &buildQueue{limit: limit, onChange: onChange}
+}
+
+func (q *buildQueue) enqueue(appName string, kind domain.BuildJobKind) *buildJob {
+	job := &buildJob{appName: appName, kind: kind, queuedAt: time.Now(), ready: make(chan struct{})}
+
+	q.mu.Lock()
+	q.jobs = append(q.jobs, job)
+	q.dispatchLocked()
+	items := q.snapshotLocked()
+	q.mu.Unlock()
+
+	q.onChange(items)
+	return job
+}
+
+// wait ждёт, пока заданию выделят слот. Ошибка - задание отменено, пока стояло в очереди.
+func (q *buildQueue) wait(ctx context.Context, job *buildJob) error {
+	select {
+	case <-job.ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// remove убирает задание из очереди (выполненное или отменённое) и освобождает его слот.
+func (q *buildQueue) remove(job *buildJob) {
+	q.mu.Lock()
+	for i, j := range q.jobs {
+		if j == job {
+			q.jobs = append(q.jobs[:i], q.jobs[i+1:]...)
+			if !job.startedAt.IsZero() {
+				q.active--
+			}
+			break
+		}
+	}
+	q.dispatchLocked()
+	items := q.snapshotLocked()
+	q.mu.Unlock()
+
+	q.onChange(items)
+}
+
+func (q *buildQueue) snapshot() []domain.BuildQueueItem {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.snapshotLocked()
+}
+
+func (q *buildQueue) dispatchLocked() {
+	limit := q.limit()
+	if limit <= 0 {
+		limit = 1
+	}
+	for _, job := range q.jobs {
+		if q.active >= limit {
+			return
+		}
+		if job.startedAt.IsZero() {
+			job.startedAt = time.Now()
+			q.active++
+			close(job.ready)
+		}
+	}
+}
+
+func (q *buildQueue) snapshotLocked() []domain.BuildQueueItem {
+	items := make([]domain.BuildQueueItem, 0, len(q.jobs))
+	position := 0
+	for _, job := range q.jobs {
+		item := domain.BuildQueueItem{
+			AppName:   job.appName,
+			Kind:      job.kind,
+			QueuedAt:  job.queuedAt,
+			StartedAt: job.startedAt,
+		}
+		if job.startedAt.IsZero() {
+			position++
+			item.Position = position
+		}
+		items = append(items, item)
+	}
+	return items
+}
@@ -0,0 +1,536 @@
+package service
+
+import (
+	"bufio"
+	"central-desktop/internal/domain"
+	"central-desktop/internal/util"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	buildStartedEventKey  = "build:started"
+	buildOutputEventKey   = "build:output"
+	buildFinishedEventKey = "build:finished"
+	testStartedEventKey   = "test:started"
+	testFinishedEventKey  = "test:finished"
+	buildQueueEventKey    = "build:queue"
+	buildOutputBatchSize  = 200
+	buildOutputInterval   = 200 * time.Millisecond
+	buildWaitDelay        = 5 * time.Second
+
+	defaultBuildMaxParallel = 2
+	// buildHistoryLimit - сколько последних сборок (всех приложений) хранить вместе с логами
+	buildHistoryLimit = 30
+)
+
+// BuildService собирает приложения в BaseDir, запускает их тесты и хранит результаты последних
+// сборки и запуска тестов. Сборка и тесты одного приложения не выполняются одновременно.
+// Все задания проходят через общую очередь: одновременно выполняется не больше BuildMaxParallel.
+// События:
+// - "build:started" -> payload: domain.BuildResult
+// - "build:output" -> payload: domain.BuildOutput (строки вывода сборки и тестов, пачками)
+// - "build:finished" -> payload: domain.BuildResult
+// - "test:started" -> payload: domain.TestRunResult
+// - "test:finished" -> payload: domain.TestRunResult
+// - "build:queue" -> payload: []domain.BuildQueueItem (вся очередь при каждом изменении)
+type BuildService struct {
+	logger          *slog.Logger
+	ctx             context.Context
+	settingsService *SettingsService
+	queue           *buildQueue
+	mu              sync.Mutex
+	running         map[string]context.CancelFunc
+	results         map[string]*domain.BuildResult
+	testResults     map[string]*domain.TestRunResult
+	history         []*domain.BuildResult
+	nextBuildID     uint64
+}
+
+func NewBuildService(lg *slog.Logger, ss *SettingsService, ctx context.Context) *BuildService {
+	lg.Info("Initializing build service")
+	s := &BuildService{
+		logger:          lg,
+		ctx:             ctx,
+		settingsService: ss,
+		running:         make(map[string]context.CancelFunc),
+		results:         make(map[string]*domain.BuildResult),
+		testResults:     make(map[string]*domain.TestRunResult),
+	}
+	s.queue = newBuildQueue(s.maxParallel, func(items []domain.BuildQueueItem) {
+		runtime.EventsEmit(s.ctx, buildQueueEventKey, items)
+	})
+
+	// история сборок живёт в памяти, логи сборок прошлых запусков JAC оставляем только последние
+	if logsDir, err := util.LogsDir(); err == nil {
+		pruneBuildLogs(lg, logsDir)
+	}
+	return s
+}
+
+// Build ставит сборку в очередь, запускает Maven или Gradle (wrapper, если есть, иначе из PATH)
+// с целями из BuildOptions и ждёт окончания. Сборка без ошибок, после которой jar не появился
+// или не обновился, считается неудачной. Вывод каждой сборки пишется в свой jac-<AppName>.build-<время>.log.
+func (s *BuildService) Build(appInfo *domain.ApplicationInfo) (*domain.BuildResult, error) {
+	tool, err := resolveBuildTool(appInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	logsDir, err := util.LogsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	bctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	if err := s.acquire(appInfo.AppName, cancel); err != nil {
+		return nil, err
+	}
+	defer s.release(appInfo.AppName)
+
+	s.mu.Lock()
+	s.nextBuildID++
+	id := s.nextBuildID
+	s.mu.Unlock()
+
+	result := &domain.BuildResult{
+		ID:       fmt.Sprintf("%d", id),
+		AppName:  appInfo.AppName,
+		Tool:     tool.Name,
+		Command:  append([]string{tool.Exe}, tool.Args...),
+		Status:   domain.BuildStatusQueued,
+		QueuedAt: time.Now(),
+		JarPath:  appInfo.JarPath,
+	}
+
+	job := s.queue.enqueue(appInfo.AppName, domain.BuildJobKindBuild)
+	defer s.queue.remove(job)
+	if err := s.queue.wait(bctx, job); err != nil {
+		result.StartedAt = time.Now()
+		s.finish(bctx, appInfo, tool, result, err)
+		return result, nil
+	}
+
+	result.Status = domain.BuildStatusRunning
+	result.StartedAt = time.Now()
+	result.LogPath = filepath.Join(logsDir, util.GetBuildLogFileName(appInfo.AppName, result.StartedAt))
+	s.logger.Info("build started", "app", appInfo.AppName, "command", result.Command,
+		"queued", result.StartedAt.Sub(result.QueuedAt))
+	runtime.EventsEmit(s.ctx, buildStartedEventKey, *result)
+
+	exitCode, runErr := s.run(bctx, appInfo, tool, result.LogPath)
+	result.ExitCode = exitCode
+	s.finish(bctx, appInfo, tool, result, runErr)
+
+	return result, nil
+}
+
+// RunTests запускает тесты приложения (integration - вместе с интеграционными) и разбирает
+// отчёты JUnit XML этого запуска. Вывод пишется в jac-<AppName>.test.log.
+func (s *BuildService) RunTests(appInfo *domain.ApplicationInfo, integration bool) (*domain.TestRunResult, error) {
+	tool, err := resolveTestTool(appInfo, integration)
+	if err != nil {
+		return nil, err
+	}
+
+	logsDir, err := util.LogsDir()
+	if err != nil {
+		return nil, err
+	}
+	logPath := filepath.Join(logsDir, util.GetTestLogFileName(appInfo.AppName))
+
+	tctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	if err := s.acquire(appInfo.AppName, cancel); err != nil {
+		return nil, err
+	}
+	defer s.release(appInfo.AppName)
+
+	result := &domain.TestRunResult{
+		AppName:     appInfo.AppName,
+		Tool:        tool.Name,
+		Integration: integration,
+		Command:     append([]string{tool.Exe}, tool.Args...),
+		LogPath:     logPath,
+	}
+
+	job := s.queue.enqueue(appInfo.AppName, domain.BuildJobKindTest)
+	defer s.queue.remove(job)
+	if err := s.queue.wait(tctx, job); err != nil {
+		result.StartedAt = time.Now()
+		s.finishTests(tctx, tool, result, err)
+		return result, nil
+	}
+
+	result.Status = domain.TestRunStatusRunning
+	result.StartedAt = time.Now()
+	s.logger.Info("tests started", "app", appInfo.AppName, "command", result.Command)
+	runtime.EventsEmit(s.ctx, testStartedEventKey, *result)
+
+	exitCode, runErr := s.run(tctx, appInfo, tool, logPath)
+	result.ExitCode = exitCode
+	s.finishTests(tctx, tool, result, runErr)
+
+	return result, nil
+}
+
+// Cancel прерывает сборку или тесты приложения вместе с дочерними процессами
+// либо убирает задание из очереди, если оно ещё не началось.
+func (s *BuildService) Cancel(appName string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cancel, ok := s.running[appName]
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+func (s *BuildService) CancelAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, cancel := range s.running {
+		cancel()
+	}
+}
+
+// LastResult - результат последней сборки приложения (nil, если сборок не было).
+func (s *BuildService) LastResult(appName string) *domain.BuildResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.results[appName]
+}
+
+// Queue - задания в очереди: выполняющиеся и ожидающие.
+func (s *BuildService) Queue() []domain.BuildQueueItem {
+	return s.queue.snapshot()
+}
+
+// History - последние сборки, новые первыми; appName фильтрует по приложению (пусто - все).
+func (s *BuildService) History(appName string) []domain.BuildResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]domain.BuildResult, 0, len(s.history))
+	for _, r := range s.history {
+		if appName == "" || r.AppName == appName {
+			res = append(res, *r)
+		}
+	}
+	return res
+}
+
+// BuildLog - вывод сборки из истории.
+func (s *BuildService) BuildLog(buildID string) (string, error) {
+	s.mu.Lock()
+	var logPath string
+	for _, r := range s.history {
+		if r.ID == buildID {
+			logPath = r.LogPath
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if logPath == "" {
+		return "", fmt.Errorf("лог сборки %s не найден", buildID)
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		return "", fmt.Errorf("не удалось прочитать лог сборки %s: %w", logPath, err)
+	}
+	return string(data), nil
+}
+
+// LastTestResult - результат последнего запуска тестов приложения (nil, если тесты не запускались).
+func (s *BuildService) LastTestResult(appName string) *domain.TestRunResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.testResults[appName]
+}
+
+func (s *BuildService) maxParallel() int {
+	if n := s.settingsService.Settings.BuildMaxParallel; n > 0 {
+		return int(n)
+	}
+	return defaultBuildMaxParallel
+}
+
+// pruneBuildLogs удаляет логи сборок, кроме buildHistoryLimit самых новых.
+func pruneBuildLogs(lg *slog.Logger, logsDir string) {
+	paths, err := filepath.Glob(filepath.Join(logsDir, "jac-*.build-*.log"))
+	if err != nil || len(paths) <= buildHistoryLimit {
+		return
+	}
+
+	// время сборки зашито в имя, но имя приложения может быть любым — сортируем по времени файла
+	modTimes := make(map[string]time.Time, len(paths))
+	for _, p := range paths {
+		if st, err := os.Stat(p); err == nil {
+			modTimes[p] = st.ModTime()
+		}
+	}
+	slices.SortFunc(paths, func(a, b string) int {
+		return modTimes[b].Compare(modTimes[a])
+	})
+
+	for _, p := range paths[buildHistoryLimit:] {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			lg.Warn("failed to remove build log", "path", p, "err", err)
+		}
+	}
+}
+
+func (s *BuildService) acquire(appName string, cancel context.CancelFunc) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.running[appName]; ok {
+		return fmt.Errorf("сборка или тесты приложения %s уже выполняются", appName)
+	}
+	s.running[appName] = cancel
+	return nil
+}
+
+func (s *BuildService) release(appName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, appName)
+}
+
+func (s *BuildService) run(ctx context.Context, appInfo *domain.ApplicationInfo, tool *buildTool,
+	logPath string) (int, error) {
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return -1, fmt.Errorf("failed to create build log %s: %w", logPath, err)
+	}
+	defer func() {
+		_ = logFile.Close()
+	}()
+
+	cmd := exec.CommandContext(ctx, tool.Exe, tool.Args...)
+	cmd.Dir = appInfo.BaseDir
+	// при совпадении имён exec берёт последнее значение, так что Env инструмента перекрывает окружение JAC
+	cmd.Env = append(os.Environ(), tool.Env...)
+	cmd.Cancel = func() error {
+		if err := util.KillProcessTree(cmd.Process.Pid); err != nil {
+			return cmd.Process.Kill()
+		}
+		return nil
+	}
+	cmd.WaitDelay = buildWaitDelay
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		HideWindow:    true,
+		CreationFlags: windows.CREATE_NO_WINDOW,
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return -1, err
+	}
+	cmd.Stderr = cmd.Stdout
+
+	if err := cmd.Start(); err != nil {
+		return -1, fmt.Errorf("failed to start %s: %w", tool.Exe, err)
+	}
+
+	s.streamOutput(appInfo.AppName, stdout, logFile)
+
+	err = cmd.Wait()
+	return cmd.ProcessState.ExitCode(), err
+}
+
+func (s *BuildService) finish(ctx context.Context, appInfo *domain.ApplicationInfo, tool *buildTool,
+	result *domain.BuildResult, runErr error) {
+	result.FinishedAt = time.Now()
+	result.DurationMs = result.FinishedAt.Sub(result.StartedAt).Milliseconds()
+
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled):
+		result.Status = domain.BuildStatusCanceled
+		result.Error = "сборка отменена"
+	case runErr != nil:
+		result.Status = domain.BuildStatusFailed
+		result.Error = runErr.Error()
+	default:
+		s.verifyJar(appInfo, tool, result)
+	}
+
+	s.mu.Lock()
+	s.results[appInfo.AppName] = result
+	s.history = append([]*domain.BuildResult{result}, s.history...)
+	var evicted []*domain.BuildResult
+	if len(s.history) > buildHistoryLimit {
+		evicted = s.history[buildHistoryLimit:]
+		s.history = s.history[:buildHistoryLimit]
+	}
+	s.mu.Unlock()
+
+	for _, r := range evicted {
+		if r.LogPath != "" {
+			if err := os.Remove(r.LogPath); err != nil && !os.IsNotExist(err) {
+				s.logger.Warn("failed to remove build log", "path", r.LogPath, "err", err)
+			}
+		}
+	}
+
+	s.logger.Info("build finished", "app", appInfo.AppName, "status", result.Status,
+		"duration", time.Duration(result.DurationMs)*time.Millisecond, "err", result.Error)
+	runtime.EventsEmit(s.ctx, buildFinishedEventKey, *result)
+
+	switch result.Status {
+	case domain.BuildStatusSuccess:
+		util.NotifySuccess(s.ctx, appInfo.AppName, "Сборка завершена")
+	case domain.BuildStatusCanceled:
+		util.NotifyWarn(s.ctx, appInfo.AppName, "Сборка отменена")
+	default:
+		util.NotifyError(s.ctx, appInfo.AppName, "Сборка не удалась: "+result.Error)
+	}
+}
+
+// finishTests разбирает отчёты JUnit XML. Упавшие тесты дают статус failed; ненулевой код выхода
+// без упавших тестов (ошибка компиляции, конфигурации) - error.
+func (s *BuildService) finishTests(ctx context.Context, tool *buildTool, result *domain.TestRunResult, runErr error) {
+	result.FinishedAt = time.Now()
+	result.DurationMs = result.FinishedAt.Sub(result.StartedAt).Milliseconds()
+
+	summary, failures, reports, parseErr := util.ParseJUnitReports(result.StartedAt, tool.ReportDirs...)
+	result.Summary = summary
+	result.Failures = failures
+	result.Reports = reports
+	if parseErr != nil {
+		s.logger.Warn("failed to parse test reports", "app", result.AppName, "err", parseErr)
+	}
+
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled):
+		result.Status = domain.TestRunStatusCanceled
+		result.Error = "запуск тестов отменён"
+	case summary.Failed+summary.Errors > 0:
+		result.Status = domain.TestRunStatusFailed
+		result.Error = fmt.Sprintf("упало тестов: %d из %d", summary.Failed+summary.Errors, summary.Total)
+	case runErr != nil:
+		result.Status = domain.TestRunStatusError
+		result.Error = runErr.Error()
+	case parseErr != nil:
+		result.Status = domain.TestRunStatusError
+		result.Error = parseErr.Error()
+	default:
+		result.Status = domain.TestRunStatusPassed
+	}
+
+	s.mu.Lock()
+	s.testResults[result.AppName] = result
+	s.mu.Unlock()
+
+	s.logger.Info("tests finished", "app", result.AppName, "status", result.Status, "total", summary.Total,
+		"failed", summary.Failed+summary.Errors, "skipped", summary.Skipped, "err", result.Error)
+	runtime.EventsEmit(s.ctx, testFinishedEventKey, *result)
+
+	switch result.Status {
+	case domain.TestRunStatusPassed:
+		util.NotifySuccess(s.ctx, result.AppName, fmt.Sprintf("Тесты прошли: %d", summary.Passed))
+	case domain.TestRunStatusCanceled:
+		util.NotifyWarn(s.ctx, result.AppName, "Запуск тестов отменён")
+	default:
+		util.NotifyError(s.ctx, result.AppName, "Тесты не прошли: "+result.Error)
+	}
+}
+
+// verifyJar проверяет, что сборка обновила JarPath (или jar по JarPattern). Если JarPath не задан, собранный jar
+// ищется в папке артефактов инструмента (target, build/libs) и возвращается в result.JarPath.
+func (s *BuildService) verifyJar(appInfo *domain.ApplicationInfo, tool *buildTool, result *domain.BuildResult) {
+	// с шаблоном после смены версии собирается jar с другим именем — он и станет JarPath при запуске
+	if strings.TrimSpace(appInfo.JarPattern) != "" {
+		if resolved, err := util.ResolveJarPattern(appInfo.BaseDir, appInfo.JarPattern); err == nil && isJarProduced(resolved, result.StartedAt) {
+			result.JarPath = resolved
+			result.JarProduced = true
+			result.Status = domain.BuildStatusSuccess
+			return
+		}
+	}
+
+	if isJarProduced(appInfo.JarPath, result.StartedAt) {
+		result.JarProduced = true
+		result.Status = domain.BuildStatusSuccess
+		return
+	}
+
+	found := findBuiltJar(tool.OutputDir, result.StartedAt)
+	switch {
+	case found != "" && strings.TrimSpace(appInfo.JarPath) == "":
+		result.JarPath = found
+		result.JarProduced = true
+		result.Status = domain.BuildStatusSuccess
+	case found != "":
+		result.Status = domain.BuildStatusFailed
+		result.Error = fmt.Sprintf("собран %s, но в настройках приложения указан %s", found, appInfo.JarPath)
+	default:
+		result.Status = domain.BuildStatusFailed
+		result.Error = fmt.Sprintf("сборка завершилась, но jar не создан или не обновлён: %s", appInfo.JarPath)
+	}
+}
+
+// streamOutput пишет вывод сборки в лог и отправляет его в UI пачками:
+// по buildOutputBatchSize строк или раз в buildOutputInterval.
+func (s *BuildService) streamOutput(appName string, r io.Reader, logFile io.Writer) {
+	lines := make(chan string, buildOutputBatchSize)
+	go func() {
+		defer close(lines)
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 64*1024), 1024*1024)
+		for sc.Scan() {
+			lines <- util.DecodeLogLine(domain.OutputEncodingAuto, sc.Text())
+		}
+		// остаток вывода дочитываем, чтобы процесс не завис на записи в pipe
+		_, _ = io.Copy(io.Discard, r)
+	}()
+
+	ticker := time.NewTicker(buildOutputInterval)
+	defer ticker.Stop()
+
+	batch := make([]string, 0, buildOutputBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		runtime.EventsEmit(s.ctx, buildOutputEventKey, domain.BuildOutput{AppName: appName, Lines: batch})
+		batch = make([]string, 0, buildOutputBatchSize)
+	}
+
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				flush()
+				return
+			}
+			_, _ = io.WriteString(logFile, line+"\n")
+			batch = append(batch, line)
+			if len(batch) >= buildOutputBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
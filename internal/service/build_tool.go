@@ -0,0 +1,271 @@
+package service
+
+import (
+	"central-desktop/internal/domain"
+	"central-desktop/internal/util"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMavenGoals  = "package -DskipTests"
+	defaultGradleTasks = "bootJar"
+
+	// unit-тесты и интеграционные (Failsafe в Maven запускается в фазе verify);
+	// cleanTest нужен, чтобы Gradle не пропустил тесты как up-to-date и переписал отчёты
+	mavenTestGoals             = "test"
+	mavenIntegrationTestGoals  = "verify"
+	gradleTestTasks            = "cleanTest test"
+	gradleIntegrationTestTasks = "cleanTest check"
+)
+
+// buildTool - чем и с какими аргументами собирать приложение. Env - переменные окружения поверх
+// окружения JAC, OutputDir - папка, куда инструмент складывает собранные jar,
+// ReportDirs - папки отчётов JUnit XML.
+type buildTool struct {
+	Name       string
+	Exe        string
+	Args       []string
+	Env        []string
+	OutputDir  string
+	ReportDirs []string
+}
+
+// resolveBuildTool выбирает инструмент из BuildOptions.Tool, иначе Maven (есть pom.xml)
+// или Gradle (есть build.gradle/settings.gradle), с целями BuildOptions.MavenGoals или задачами GradleTasks.
+func resolveBuildTool(appInfo *domain.ApplicationInfo) (*buildTool, error) {
+	return resolveTool(appInfo, appInfo.BuildOptions.MavenGoals, appInfo.BuildOptions.GradleTasks)
+}
+
+// resolveTestTool - тот же инструмент, что и для сборки, с задачей запуска тестов.
+func resolveTestTool(appInfo *domain.ApplicationInfo, integration bool) (*buildTool, error) {
+	if integration {
+		return resolveTool(appInfo, mavenIntegrationTestGoals, gradleIntegrationTestTasks)
+	}
+	return resolveTool(appInfo, mavenTestGoals, gradleTestTasks)
+}
+
+func resolveTool(appInfo *domain.ApplicationInfo, mavenGoals string, gradleTasks string) (*buildTool, error) {
+	opts := &appInfo.BuildOptions
+	switch effectiveBuildTool(appInfo) {
+	case domain.BuildToolMaven:
+		exe, err := mavenExecutable(appInfo.BaseDir)
+		if err != nil {
+			return nil, err
+		}
+		return &buildTool{
+			Name:      string(domain.BuildToolMaven),
+			Exe:       exe,
+			Args:      mavenArgs(mavenGoals, opts),
+			Env:       buildEnv(opts),
+			OutputDir: filepath.Join(appInfo.BaseDir, "target"),
+			ReportDirs: []string{
+				filepath.Join(appInfo.BaseDir, "target", "surefire-reports"),
+				filepath.Join(appInfo.BaseDir, "target", "failsafe-reports"),
+			},
+		}, nil
+	case domain.BuildToolGradle:
+		exe, err := gradleExecutable(appInfo.BaseDir, appInfo.GitRoot)
+		if err != nil {
+			return nil, err
+		}
+		return &buildTool{
+			Name:       string(domain.BuildToolGradle),
+			Exe:        exe,
+			Args:       gradleArgs(gradleTasks, opts),
+			Env:        buildEnv(opts),
+			OutputDir:  filepath.Join(appInfo.BaseDir, "build", "libs"),
+			ReportDirs: []string{filepath.Join(appInfo.BaseDir, "build", "test-results")},
+		}, nil
+	default:
+		return nil, fmt.Errorf("в папке приложения %s нет ни pom.xml, ни build.gradle", appInfo.AppName)
+	}
+}
+
+// migrateBuildGoals переносит buildGoals старого central-info.json в цели или задачи
+// BuildOptions того инструмента, которым собирается приложение.
+func migrateBuildGoals(appInfo *domain.ApplicationInfo) {
+	goals := strings.TrimSpace(appInfo.LegacyBuildGoals)
+	appInfo.LegacyBuildGoals = ""
+	if goals == "" {
+		return
+	}
+	opts := &appInfo.BuildOptions
+	switch effectiveBuildTool(appInfo) {
+	case domain.BuildToolGradle:
+		if opts.GradleTasks == "" {
+			opts.GradleTasks = goals
+		}
+	default:
+		if opts.MavenGoals == "" {
+			opts.MavenGoals = goals
+		}
+	}
+}
+
+// effectiveBuildTool - инструмент из настроек или определённый по файлам сборки (пусто — не найден).
+func effectiveBuildTool(appInfo *domain.ApplicationInfo) domain.BuildTool {
+	switch {
+	case appInfo.BuildOptions.Tool != domain.BuildToolAuto:
+		return appInfo.BuildOptions.Tool
+	case appInfo.HasMaven:
+		return domain.BuildToolMaven
+	case appInfo.HasGradle:
+		return domain.BuildToolGradle
+	default:
+		return domain.BuildToolAuto
+	}
+}
+
+// buildEnv - JAVA_HOME и PATH для сборки выбранным JDK (mvnw и gradlew берут JDK из JAVA_HOME).
+func buildEnv(opts *domain.BuildOptions) []string {
+	if opts.JavaHome == "" {
+		return nil
+	}
+	path := filepath.Join(opts.JavaHome, "bin") + string(os.PathListSeparator) + os.Getenv("PATH")
+	return []string{"JAVA_HOME=" + opts.JavaHome, "PATH=" + path}
+}
+
+// mavenExecutable - Maven Wrapper из папки приложения, иначе mvn из PATH.
+func mavenExecutable(baseDir string) (string, error) {
+	wrapper := filepath.Join(baseDir, "mvnw.cmd")
+	if util.IsRegularFile(wrapper) {
+		return wrapper, nil
+	}
+
+	exe, err := exec.LookPath("mvn")
+	if err != nil {
+		return "", fmt.Errorf("не найден ни mvnw.cmd в %s, ни mvn в PATH", baseDir)
+	}
+	return exe, nil
+}
+
+// gradleExecutable - Gradle Wrapper из папки приложения или ближайшей родительской папки
+// в пределах репозитория (у многомодульных проектов он лежит в корне), иначе gradle из PATH.
+func gradleExecutable(baseDir string, gitRoot string) (string, error) {
+	dir := filepath.Clean(baseDir)
+	root := filepath.Clean(gitRoot)
+	for {
+		wrapper := filepath.Join(dir, "gradlew.bat")
+		if util.IsRegularFile(wrapper) {
+			return wrapper, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if gitRoot == "" || dir == root || parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	exe, err := exec.LookPath("gradle")
+	if err != nil {
+		return "", fmt.Errorf("не найден ни gradlew.bat в %s, ни gradle в PATH", baseDir)
+	}
+	return exe, nil
+}
+
+// mavenArgs - цели сборки в batch-режиме (без интерактивных запросов и цветного вывода)
+// с параметрами из BuildOptions перед целями.
+func mavenArgs(goals string, opts *domain.BuildOptions) []string {
+	goalArgs := strings.Fields(goals)
+	if len(goalArgs) == 0 {
+		goalArgs = strings.Fields(defaultMavenGoals)
+	}
+
+	var args []string
+	if !slices.Contains(goalArgs, "-B") && !slices.Contains(goalArgs, "--batch-mode") {
+		args = append(args, "-B")
+	}
+	if opts.Offline {
+		args = append(args, "-o")
+	}
+	if opts.SettingsFile != "" {
+		args = append(args, "-s", opts.SettingsFile)
+	}
+	if opts.LocalRepository != "" {
+		args = append(args, "-Dmaven.repo.local="+opts.LocalRepository)
+	}
+	if len(opts.Profiles) > 0 {
+		args = append(args, "-P"+strings.Join(opts.Profiles, ","))
+	}
+	args = append(args, propertyArgs("-D", opts.Properties)...)
+	return append(args, goalArgs...)
+}
+
+// gradleArgs - задачи сборки с простым построчным выводом и параметрами из BuildOptions перед задачами.
+func gradleArgs(tasks string, opts *domain.BuildOptions) []string {
+	taskArgs := strings.Fields(tasks)
+	if len(taskArgs) == 0 {
+		taskArgs = strings.Fields(defaultGradleTasks)
+	}
+
+	var args []string
+	if !slices.ContainsFunc(taskArgs, func(a string) bool { return strings.HasPrefix(a, "--console") }) {
+		args = append(args, "--console=plain")
+	}
+	if opts.Offline {
+		args = append(args, "--offline")
+	}
+	args = append(args, propertyArgs("-P", opts.Properties)...)
+	return append(args, taskArgs...)
+}
+
+func propertyArgs(prefix string, props []domain.EnvVariable) []string {
+	var args []string
+	for _, p := range props {
+		if p.IsActive {
+			args = append(args, prefix+p.Name+"="+p.Value)
+		}
+	}
+	return args
+}
+
+// findBuiltJar ищет в outputDir самый свежий исполняемый jar, записанный после начала сборки.
+// Вспомогательные артефакты (-plain, -sources, -javadoc, -tests) пропускаются.
+func findBuiltJar(outputDir string, startedAt time.Time) string {
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		return ""
+	}
+
+	var found string
+	var foundTime time.Time
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.EqualFold(filepath.Ext(name), ".jar") || util.IsAuxiliaryJar(name) {
+			continue
+		}
+		path := filepath.Join(outputDir, name)
+		if !isJarProduced(path, startedAt) {
+			continue
+		}
+		st, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if found == "" || st.ModTime().After(foundTime) {
+			found = path
+			foundTime = st.ModTime()
+		}
+	}
+	return found
+}
+
+// isJarProduced - jar существует и записан не раньше начала сборки
+// (с запасом в секунду на точность времени модификации файла).
+func isJarProduced(jarPath string, startedAt time.Time) bool {
+	if strings.TrimSpace(jarPath) == "" {
+		return false
+	}
+	st, err := os.Stat(jarPath)
+	if err != nil || st.IsDir() {
+		return false
+	}
+	return !st.ModTime().Before(startedAt.Add(-time.Second))
+}
@@ -10,76 +10,369 @@ import (
 	"fmt"
 	"log/slog"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	readyGracePeriod        = 10 * time.Second
+	defaultReadinessTimeout = 3 * time.Minute
+	rebuildProgressEventKey = "rebuild:progress"
 )
 
+// CentralService управляет приложениями из central-info.json.
+// centralInfo не изменяется на месте: изменения собираются в новой копии, которая сохраняется
+// и подменяет текущую под mu. Полученный через snapshot указатель можно читать без блокировки.
+// События:
+// - "rebuild:progress" -> payload: dto.RebuildResultDTO (этапы пересборки и перезапуска)
 type CentralService struct {
-	logger           *slog.Logger
-	centralInfo      *domain.CentralInfo
-	runAllInProgress atomic.Bool
-	ctx              context.Context
-	settingsService  *SettingsService
-	gitService       *GitService
+	logger               *slog.Logger
+	mu                   sync.RWMutex
+	centralInfo          *domain.CentralInfo
+	runAllInProgress     atomic.Bool
+	rebuildAllInProgress atomic.Bool
+	ctx                  context.Context
+	settingsService      *SettingsService
+	gitService           *GitService
+	alertService         *AlertService
+	diagService          *DiagnosticsService
+	gitStatusMonitor     *GitStatusMonitor
+	buildService         *BuildService
+	jarStatusChecker     *JarStatusChecker
 }
 
-func NewCentralService(lg *slog.Logger, ss *SettingsService, gs *GitService, ctx context.Context) *CentralService {
+func NewCentralService(lg *slog.Logger, ss *SettingsService, gs *GitService, as *AlertService, ds *DiagnosticsService,
+	gsm *GitStatusMonitor, bs *BuildService, jsc *JarStatusChecker, ctx context.Context) *CentralService {
 	lg.Info("Initializing central service")
 	ci, err := util.ReadOrCreateCentralInfo(ss.Settings.CentralInfoPath)
 	if err != nil {
 		panic(err)
 	}
 
-	return &CentralService{
-		logger:          lg,
-		settingsService: ss,
-		gitService:      gs,
-		centralInfo:     ci,
-		ctx:             ctx,
+	// central-info.json, созданный до появления оповещений, — подставляем правила по умолчанию
+	if ci.GlobalAlertRules == nil {
+		ci.GlobalAlertRules = util.DefaultAlertRules()
+	}
+
+	if err := as.Watch(ci); err != nil {
+		lg.Error("Failed to start alert watcher", "err", err)
+	}
+
+	s := &CentralService{
+		logger:           lg,
+		settingsService:  ss,
+		gitService:       gs,
+		alertService:     as,
+		diagService:      ds,
+		gitStatusMonitor: gsm,
+		buildService:     bs,
+		jarStatusChecker: jsc,
+		centralInfo:      ci,
+		ctx:              ctx,
+	}
+
+	// gitRoot мог не сохраниться в старом central-info.json, а репозиторий — переехать
+	for i := range ci.ApplicationInfos {
+		s.resolveRepository(&ci.ApplicationInfos[i])
+		migrateBuildGoals(&ci.ApplicationInfos[i])
 	}
+
+	gsm.Start(time.Duration(ss.Settings.GitStatusRefreshSec)*time.Second, s.gitRepoRefs)
+	jsc.Start(time.Duration(ss.Settings.GitStatusRefreshSec)*time.Second, s.jarApps)
+	ss.OnSave(func(prev domain.AppSettings) {
+		if prev.GitStatusRefreshSec != ss.Settings.GitStatusRefreshSec {
+			s.logger.Info("git status refresh interval changed, restarting monitor",
+				"old", prev.GitStatusRefreshSec, "new", ss.Settings.GitStatusRefreshSec)
+			gsm.Start(time.Duration(ss.Settings.GitStatusRefreshSec)*time.Second, s.gitRepoRefs)
+			jsc.Start(time.Duration(ss.Settings.GitStatusRefreshSec)*time.Second, s.jarApps)
+		}
+	})
+
+	return s
 }
 
 func (s *CentralService) GetCentralInfoDTO() (*dto.CentralInfoDTO, error) {
-	ciDTO := mapper.ToCentralInfoDTO(s.centralInfo)
+	ci := s.snapshot()
+	ciDTO := mapper.ToCentralInfoDTO(ci)
 	err := s.setPIDInfo(&ciDTO.ApplicationInfos)
 	if err != nil {
 		return nil, err
 	}
+	for i := range ciDTO.ApplicationInfos {
+		ai := &ciDTO.ApplicationInfos[i]
+		if ai.HasGit {
+			ai.GitStatus = s.gitStatusMonitor.Get(ai.AppName)
+			ai.SharedRepoWith = s.sharedRepoApps(&ci.ApplicationInfos[i])
+		}
+		ai.LastBuild = s.buildService.LastResult(ai.AppName)
+		ai.LastTestRun = s.buildService.LastTestResult(ai.AppName)
+		resolved := s.withResolvedJar(&ci.ApplicationInfos[i])
+		ai.ResolvedJarPath = resolved.JarPath
+		ai.JarStatus = s.jarStatusChecker.Cached(resolved)
+	}
 	return &ciDTO, nil
 }
 
 func (s *CentralService) Save(info *domain.CentralInfo) (*dto.CentralInfoDTO, error) {
+	if err := ValidateAlertRules(info.GlobalAlertRules); err != nil {
+		return nil, err
+	}
+	for _, ai := range info.ApplicationInfos {
+		if err := ValidateAlertRules(ai.AlertRules); err != nil {
+			return nil, fmt.Errorf("%s: %w", ai.AppName, err)
+		}
+		if err := util.ValidateOutputEncoding(ai.OutputEncoding); err != nil {
+			return nil, fmt.Errorf("%s: %w", ai.AppName, err)
+		}
+		if err := util.ValidateJarPattern(ai.JarPattern); err != nil {
+			return nil, fmt.Errorf("%s: %w", ai.AppName, err)
+		}
+		if err := util.ValidateBuildOptions(&ai); err != nil {
+			return nil, fmt.Errorf("%s: %w", ai.AppName, err)
+		}
+	}
+
 	sort.Slice(info.ApplicationInfos, func(i, j int) bool {
 		return info.ApplicationInfos[i].StartOrder < info.ApplicationInfos[j].StartOrder
 	})
 
-	s.centralInfo = info
+	removedWorktrees := s.removedWorktrees(info)
 
-	for i := range s.centralInfo.ApplicationInfos {
-		appInfo := &s.centralInfo.ApplicationInfos[i]
+	for i := range info.ApplicationInfos {
+		appInfo := &info.ApplicationInfos[i]
 
-		hasGit, err := util.HasGitFolder(appInfo.BaseDir)
-		if err != nil {
-			s.logger.Error("Failed to check if git folder exists", "err", err, "appInfo", appInfo)
-		}
-		appInfo.HasGit = hasGit
+		s.resolveRepository(appInfo)
 
 		hasMaven, err := util.HasMaven(appInfo.BaseDir)
 		if err != nil {
-			s.logger.Error("Failed to check if maven pom.xml exists", "err", err, "appInfo", appInfo)
+			s.logger.Error("Failed to check if maven pom.xml exists", "err", err, "app", appInfo.AppName, "baseDir", appInfo.BaseDir)
 		}
 		appInfo.HasMaven = hasMaven
+
+		hasGradle, err := util.HasGradle(appInfo.BaseDir)
+		if err != nil {
+			s.logger.Error("Failed to check if gradle build exists", "err", err, "app", appInfo.AppName, "baseDir", appInfo.BaseDir)
+		}
+		appInfo.HasGradle = hasGradle
 	}
 
-	err := util.WriteJSON(util.BuildCentralInfoFilePath(s.settingsService.Settings.CentralInfoPath), s.centralInfo)
+	s.mu.Lock()
+	err := util.WriteJSON(util.BuildCentralInfoFilePath(s.settingsService.Settings.CentralInfoPath), info)
+	if err == nil {
+		s.centralInfo = info
+	}
+	s.mu.Unlock()
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.alertService.Watch(info); err != nil {
+		s.logger.Error("Failed to restart alert watcher", "err", err)
+	}
+
+	s.cleanupWorktrees(removedWorktrees)
+
 	return s.GetCentralInfoDTO()
 }
 
+// worktreeRef - worktree удалённого приложения и репозиторий, в котором он зарегистрирован.
+type worktreeRef struct {
+	appName  string
+	repoPath string
+	path     string
+}
+
+// CreateWorktree создаёт git worktree из репозитория приложения req.AppName и регистрирует
+// его как отдельное приложение с теми же настройками, своим jar и портом.
+// Jar ищется по тому же относительному пути, что и у исходного приложения.
+func (s *CentralService) CreateWorktree(req *dto.WorktreeRequestDTO) (*dto.CentralInfoDTO, error) {
+	s.logger.Info("execute create worktree", "app", req.AppName, "branch", req.Branch, "path", req.Path)
+	parent, err := s.getGitAppInfo(req.AppName)
+	if err != nil {
+		return nil, err
+	}
+	if parent.WorktreeOf != "" {
+		return nil, fmt.Errorf("приложение %s само является worktree приложения %s", parent.AppName, parent.WorktreeOf)
+	}
+
+	branch := strings.TrimSpace(req.Branch)
+	if branch == "" {
+		return nil, errors.New("не указана ветка для worktree")
+	}
+
+	parentRoot := repoPath(parent)
+	baseRel, err := filepath.Rel(parentRoot, parent.BaseDir)
+	if err != nil || isOutsideDir(baseRel) {
+		return nil, fmt.Errorf("папка приложения %s находится вне репозитория: %s", parent.AppName, parent.BaseDir)
+	}
+	jarRel, err := filepath.Rel(parentRoot, parent.JarPath)
+	if err != nil || isOutsideDir(jarRel) {
+		return nil, fmt.Errorf("jar приложения %s находится вне папки репозитория: %s", parent.AppName, parent.JarPath)
+	}
+
+	appName := strings.TrimSpace(req.NewAppName)
+	if appName == "" {
+		appName = fmt.Sprintf("%s (%s)", parent.AppName, sanitizeArchiveName(branch))
+	}
+	if _, err := s.getAppInfoByName(appName); err == nil {
+		return nil, fmt.Errorf("приложение %s уже существует", appName)
+	}
+
+	if req.ServerPort > 0 {
+		for _, ai := range s.snapshot().ApplicationInfos {
+			if util.ServerPort(&ai) == req.ServerPort {
+				return nil, fmt.Errorf("порт %d уже назначен приложению %s", req.ServerPort, ai.AppName)
+			}
+		}
+	}
+
+	path := strings.TrimSpace(req.Path)
+	if path == "" {
+		path = filepath.Join(filepath.Clean(parentRoot)+"-worktrees", sanitizeArchiveName(branch))
+	}
+
+	localBranch, err := s.gitService.AddWorktree(parentRoot, path, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	wt := *parent
+	wt.AppName = appName
+	wt.BaseDir = filepath.Join(path, baseRel)
+	wt.GitRoot = path
+	wt.JarPath = filepath.Join(path, jarRel)
+	wt.WorktreeOf = parent.AppName
+	wt.ServerPort = req.ServerPort
+	wt.IsActive = false
+	wt.EnvVariables = slices.Clone(parent.EnvVariables)
+	wt.AppArguments = slices.Clone(parent.AppArguments)
+	if req.ServerPort > 0 {
+		// порт родителя из AppArguments перекрыл бы ServerPort (см. buildJavaArgs)
+		wt.AppArguments = util.WithoutJvmProperty(parent.AppArguments, "server.port")
+	}
+	wt.AlertRules = slices.Clone(parent.AlertRules)
+
+	ci := s.snapshot()
+	info := *ci
+	info.ApplicationInfos = append(slices.Clone(ci.ApplicationInfos), wt)
+
+	res, err := s.Save(&info)
+	if err != nil {
+		// приложение не сохранилось — worktree без приложения не нужен
+		if rerr := s.gitService.RemoveWorktree(parentRoot, path, true); rerr != nil {
+			s.logger.Warn("failed to remove worktree", "path", path, "err", rerr)
+		}
+		return nil, err
+	}
+
+	s.logger.Info("worktree created", "app", appName, "branch", localBranch, "path", path)
+	return res, nil
+}
+
+// RemoveWorktree удаляет worktree приложения и само приложение. Запущенное приложение
+// не удаляется; без force git откажется удалять worktree с незакоммиченными изменениями.
+func (s *CentralService) RemoveWorktree(appName string, force bool) (*dto.CentralInfoDTO, error) {
+	s.logger.Info("execute remove worktree", "app", appName, "force", force)
+	appInfo, err := s.getAppInfoByName(appName)
+	if err != nil {
+		return nil, err
+	}
+	if appInfo.WorktreeOf == "" {
+		return nil, fmt.Errorf("приложение %s не является worktree", appName)
+	}
+
+	processes, err := s.GetRunningProcesses()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range processes {
+		if p.Name == appName {
+			return nil, fmt.Errorf("остановите приложение %s перед удалением worktree", appName)
+		}
+	}
+
+	if err := s.gitService.RemoveWorktree(s.worktreeRepoPath(appInfo), repoPath(appInfo), force); err != nil {
+		return nil, err
+	}
+
+	ci := s.snapshot()
+	info := *ci
+	info.ApplicationInfos = slices.DeleteFunc(slices.Clone(ci.ApplicationInfos), func(ai domain.ApplicationInfo) bool {
+		return ai.AppName == appName
+	})
+	return s.Save(&info)
+}
+
+func (s *CentralService) ListWorktrees(appName string) ([]domain.Worktree, error) {
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return nil, err
+	}
+	return s.gitService.ListWorktrees(repoPath(appInfo))
+}
+
+// removedWorktrees - worktree-приложения текущей конфигурации, которых нет в info.
+func (s *CentralService) removedWorktrees(info *domain.CentralInfo) []worktreeRef {
+	ci := s.snapshot()
+	if ci == nil {
+		return nil
+	}
+
+	kept := make(map[string]bool, len(info.ApplicationInfos))
+	for _, ai := range info.ApplicationInfos {
+		kept[ai.AppName] = true
+	}
+
+	var res []worktreeRef
+	for i := range ci.ApplicationInfos {
+		ai := &ci.ApplicationInfos[i]
+		if ai.WorktreeOf == "" || kept[ai.AppName] {
+			continue
+		}
+		res = append(res, worktreeRef{
+			appName:  ai.AppName,
+			repoPath: s.worktreeRepoPath(ai),
+			path:     repoPath(ai),
+		})
+	}
+	return res
+}
+
+// cleanupWorktrees удаляет worktree приложений, удалённых из конфигурации.
+// Worktree с изменениями не трогается — об этом приходит предупреждение.
+func (s *CentralService) cleanupWorktrees(refs []worktreeRef) {
+	for _, ref := range refs {
+		if err := s.gitService.RemoveWorktree(ref.repoPath, ref.path, false); err != nil {
+			s.logger.Warn("failed to remove worktree", "app", ref.appName, "path", ref.path, "err", err)
+			util.NotifyWarn(s.ctx, ref.appName, fmt.Sprintf("Worktree %s не удалён: %s", ref.path, err))
+			continue
+		}
+		s.logger.Info("worktree removed", "app", ref.appName, "path", ref.path)
+	}
+}
+
+// worktreeRepoPath - основной репозиторий worktree-приложения; если исходное приложение
+// уже удалено, git команды выполняются из самого worktree.
+func (s *CentralService) worktreeRepoPath(appInfo *domain.ApplicationInfo) string {
+	for _, ai := range s.snapshot().ApplicationInfos {
+		if ai.AppName == appInfo.WorktreeOf {
+			return repoPath(&ai)
+		}
+	}
+	return repoPath(appInfo)
+}
+
+// isOutsideDir - относительный путь (результат filepath.Rel) выходит за пределы папки.
+func isOutsideDir(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 func (s *CentralService) RunAll() {
 	// защита от повторного запуска
 	if !s.runAllInProgress.CompareAndSwap(false, true) {
@@ -92,7 +385,7 @@ func (s *CentralService) RunAll() {
 
 		duration := time.Duration(s.settingsService.Settings.ApplicationStartingDelaySec) * time.Second
 
-		apps := s.centralInfo.ApplicationInfos
+		apps := s.snapshot().ApplicationInfos
 		for i, info := range apps {
 			if info.IsActive {
 				_, err := s.RunApplication(info.AppName)
@@ -116,6 +409,7 @@ func (s *CentralService) RunApplication(appName string) (*util.CommandResult, er
 	if err != nil {
 		return nil, err
 	}
+	target := s.withResolvedJar(found)
 
 	processes, err := util.ListJavaProcesses()
 	if err != nil {
@@ -124,11 +418,30 @@ func (s *CentralService) RunApplication(appName string) (*util.CommandResult, er
 	}
 
 	for _, process := range processes {
-		if process.Path == found.JarPath {
+		if process.Path == found.JarPath || process.Path == target.JarPath {
 			return nil, errors.New(fmt.Sprintf("Приложение %s уже запущено", appName))
 		}
 	}
 
+	if found.RebuildBeforeRun {
+		if status := s.jarStatusChecker.Check(target); status.Outdated {
+			s.logger.Info("jar is outdated, rebuilding before run", "app", appName, "reason", status.Reason)
+			util.NotifyInfo(s.ctx, appName, "Jar устарел, пересборка перед запуском")
+			res, err := s.BuildApplication(appName)
+			if err != nil {
+				return nil, err
+			}
+			if res.Status != domain.BuildStatusSuccess {
+				return nil, fmt.Errorf("сборка приложения %s перед запуском не удалась: %s", appName, res.Error)
+			}
+		}
+	}
+
+	// после сборки по шаблону может найтись jar новой версии
+	if found, err = s.resolveJarPath(appName); err != nil {
+		return nil, err
+	}
+
 	runFunc := util.RunApplication
 	if s.settingsService.Settings.StartQuietMode {
 		runFunc = util.RunApplicationSilent
@@ -143,6 +456,54 @@ func (s *CentralService) RunApplication(appName string) (*util.CommandResult, er
 	return cr, nil
 }
 
+// withResolvedJar - копия appInfo с JarPath, найденным по JarPattern, без сохранения.
+// Если шаблон не задан или по нему ничего не найдено, возвращается appInfo.
+func (s *CentralService) withResolvedJar(appInfo *domain.ApplicationInfo) *domain.ApplicationInfo {
+	if strings.TrimSpace(appInfo.JarPattern) == "" {
+		return appInfo
+	}
+	resolved, err := util.ResolveJarPattern(appInfo.BaseDir, appInfo.JarPattern)
+	if err != nil || resolved == appInfo.JarPath {
+		return appInfo
+	}
+	res := *appInfo
+	res.JarPath = resolved
+	return &res
+}
+
+// resolveJarPath подставляет в JarPath самый новый jar по JarPattern (например, после смены версии)
+// и сохраняет его, чтобы статус запущенного процесса определялся по фактическому пути.
+// Возвращает актуальное описание приложения.
+func (s *CentralService) resolveJarPath(appName string) (*domain.ApplicationInfo, error) {
+	appInfo, err := s.getAppInfoByName(appName)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(appInfo.JarPattern) == "" {
+		return appInfo, nil
+	}
+
+	resolved, err := util.ResolveJarPattern(appInfo.BaseDir, appInfo.JarPattern)
+	if err != nil {
+		return nil, err
+	}
+	if resolved == appInfo.JarPath {
+		return appInfo, nil
+	}
+
+	s.logger.Info("jar path resolved by pattern", "app", appName, "pattern", appInfo.JarPattern,
+		"old", appInfo.JarPath, "new", resolved)
+	appInfo, err = s.updateAppInfo(appName, func(ai *domain.ApplicationInfo) {
+		ai.JarPath = resolved
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.jarStatusChecker.Invalidate(appName)
+	util.NotifyInfo(s.ctx, appName, "Jar по шаблону: "+filepath.Base(resolved))
+	return appInfo, nil
+}
+
 func (s *CentralService) StopApplication(appName string) error {
 	processes, err := s.GetRunningProcesses()
 	if err != nil {
@@ -184,8 +545,9 @@ func (s *CentralService) GetRunningProcesses() ([]*dto.RunningProcessDTO, error)
 		return nil, fmt.Errorf("не удалось получить список запущенных Java процессов")
 	}
 
-	appByPath := make(map[string]string, len(s.centralInfo.ApplicationInfos))
-	for _, ai := range s.centralInfo.ApplicationInfos {
+	apps := s.snapshot().ApplicationInfos
+	appByPath := make(map[string]string, len(apps))
+	for _, ai := range apps {
 		appByPath[ai.JarPath] = ai.AppName
 	}
 
@@ -217,7 +579,7 @@ func (s *CentralService) StartLog(logTailer *util.LogTailer, appName string) err
 
 	logPath := filepath.Join(logsDir, util.GetLogFileName(found.AppName))
 
-	err = logTailer.Start(s.ctx, logPath)
+	err = logTailer.Start(s.ctx, logPath, found.OutputEncoding)
 	if err != nil {
 		return err
 	}
@@ -229,6 +591,49 @@ func (s *CentralService) StopLog(logTailer *util.LogTailer) {
 	logTailer.Stop()
 }
 
+func (s *CentralService) StartMergedLog(mergedTailer *util.MergedLogTailer, appNames []string, traceID string) error {
+	if len(appNames) == 0 {
+		return errors.New("не выбрано ни одного приложения для просмотра логов")
+	}
+
+	logsDir, err := util.LogsDir()
+	if err != nil {
+		return err
+	}
+
+	sources := make([]util.LogSource, 0, len(appNames))
+	for _, appName := range appNames {
+		found, err := s.getAppInfoByName(appName)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, util.LogSource{
+			AppName:  found.AppName,
+			Path:     filepath.Join(logsDir, util.GetLogFileName(found.AppName)),
+			Encoding: found.OutputEncoding,
+		})
+	}
+
+	return mergedTailer.Start(s.ctx, sources, s.settingsService.Settings.TraceIDPattern, traceID)
+}
+
+func (s *CentralService) StopMergedLog(mergedTailer *util.MergedLogTailer) {
+	mergedTailer.Stop()
+}
+
+func (s *CentralService) ExportDiagnostics(appNames []string, archivePath string) error {
+	apps := make([]*domain.ApplicationInfo, 0, len(appNames))
+	for _, appName := range appNames {
+		found, err := s.getAppInfoByName(appName)
+		if err != nil {
+			return err
+		}
+		apps = append(apps, found)
+	}
+
+	return s.diagService.Export(archivePath, apps, s.snapshot().GlobalVariables)
+}
+
 func (s *CentralService) GetGitBranches(appName string, fetch bool) (*domain.Branches, error) {
 	s.logger.Info("execute get git branches", "app", appName)
 	appInfo, err := s.getAppInfoByName(appName)
@@ -238,47 +643,923 @@ func (s *CentralService) GetGitBranches(appName string, fetch bool) (*domain.Bra
 	if !appInfo.HasGit {
 		return nil, fmt.Errorf("отсутствует Git репозиторий для приложения %s", appName)
 	}
-	return s.gitService.ListBranches(appInfo.BaseDir, fetch)
+	return s.gitService.ListBranches(repoPath(appInfo), fetch)
 }
 
-func (s *CentralService) CheckoutBranch(appName string, branch string) error {
-	s.logger.Info("execute get git branches", "app", appName, "branch", branch)
+func (s *CentralService) CheckoutBranch(appName string, branch string, opts domain.CheckoutOptions) (*domain.CheckoutResult, error) {
+	s.logger.Info("execute checkout branch", "app", appName, "branch", branch, "options", opts)
 	appInfo, err := s.getAppInfoByName(appName)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !appInfo.HasGit {
-		return fmt.Errorf("не указан Git репозиторий для приложения %s", appName)
+		return nil, fmt.Errorf("не указан Git репозиторий для приложения %s", appName)
+	}
+
+	res, err := s.gitService.CheckoutBranch(repoPath(appInfo), branch, opts)
+
+	if shared := s.sharedRepoApps(appInfo); len(shared) > 0 && err == nil && res != nil {
+		res.SharedWith = shared
+		util.NotifyWarn(s.ctx, appName, fmt.Sprintf("Ветка %s переключена и для приложений из того же репозитория: %s",
+			res.Branch, strings.Join(shared, ", ")))
 	}
-	return s.gitService.CheckoutBranch(appInfo.BaseDir, branch)
+
+	s.refreshGitStatus(appInfo)
+	return res, err
 }
 
-func (s *CentralService) setPIDInfo(appInfos *[]dto.ApplicationInfoDTO) error {
-	processes, err := util.ListJavaProcesses()
+// SwitchFeatureBranch переключает репозитории выбранных приложений на req.Branch там, где
+// ветка есть (локально или на remote), остальные — на ветку по умолчанию (DefaultBranch
+// приложения или DefaultGitBranch из настроек). Приложения с общим репозиторием
+// переключаются один раз. С req.Restart запущенные приложения, у которых сменилась ветка,
+// перезапускаются в порядке StartOrder; с req.Rebuild они перед этим пересобираются.
+func (s *CentralService) SwitchFeatureBranch(req *dto.FeatureBranchRequestDTO) ([]dto.FeatureBranchResultDTO, error) {
+	branch := strings.TrimSpace(req.Branch)
+	if branch == "" {
+		return nil, errors.New("не указана ветка для переключения")
+	}
+	s.logger.Info("execute switch feature branch", "branch", branch, "apps", req.AppNames, "fetch", req.Fetch)
+
+	selected := make(map[string]bool, len(req.AppNames))
+	for _, appName := range req.AppNames {
+		selected[appName] = true
+	}
+
+	type repoGroup struct {
+		gitPath string
+		apps    []*domain.ApplicationInfo
+	}
+	var groups []*repoGroup
+	groupByPath := make(map[string]*repoGroup)
+	ci := s.snapshot()
+	for i := range ci.ApplicationInfos {
+		ai := &ci.ApplicationInfos[i]
+		if !ai.HasGit || (len(selected) > 0 && !selected[ai.AppName]) {
+			continue
+		}
+		key := filepath.Clean(repoPath(ai))
+		g, ok := groupByPath[key]
+		if !ok {
+			g = &repoGroup{gitPath: repoPath(ai)}
+			groupByPath[key] = g
+			groups = append(groups, g)
+		}
+		g.apps = append(g.apps, ai)
+	}
+
+	groupResults := make([][]dto.FeatureBranchResultDTO, len(groups))
+	changed := make([]bool, len(groups))
+	runParallel(int(s.settingsService.Settings.GitMaxParallel), len(groups), func(i int) {
+		groupResults[i], changed[i] = s.switchRepoBranch(groups[i].gitPath, groups[i].apps, branch, req)
+	})
+
+	results := make([]dto.FeatureBranchResultDTO, 0, len(groups))
+	toRestart := make(map[string]bool)
+	for i := range groups {
+		results = append(results, groupResults[i]...)
+		if changed[i] {
+			for _, ai := range groups[i].apps {
+				toRestart[ai.AppName] = true
+			}
+		}
+	}
+
+	if (req.Restart || req.Rebuild) && len(toRestart) > 0 {
+		s.restartChangedApplications(results, toRestart, req.Rebuild)
+	}
+
+	return results, nil
+}
+
+// switchRepoBranch переключает один репозиторий; второй результат — сменилась ли ветка.
+func (s *CentralService) switchRepoBranch(gitPath string, apps []*domain.ApplicationInfo, branch string,
+	req *dto.FeatureBranchRequestDTO) ([]dto.FeatureBranchResultDTO, bool) {
+
+	results := make([]dto.FeatureBranchResultDTO, len(apps))
+	for i, ai := range apps {
+		results[i].AppName = ai.AppName
+	}
+	finish := func(target string, status dto.FeatureBranchStatus, err error) ([]dto.FeatureBranchResultDTO, bool) {
+		for i := range results {
+			results[i].Branch = target
+			results[i].Status = status
+			if err != nil {
+				results[i].Error = err.Error()
+			}
+		}
+		return results, false
+	}
+
+	if req.Fetch {
+		if err := s.gitService.fetch(gitPath); err != nil {
+			return finish("", dto.FeatureBranchFailed, fmt.Errorf("fetch failed: %w", err))
+		}
+	}
+
+	current, _, err := s.gitService.CurrentRevision(gitPath)
 	if err != nil {
-		return fmt.Errorf("не удалось получить список запущенных Java процессов")
+		return finish("", dto.FeatureBranchFailed, err)
 	}
 
-	appByPath := make(map[string]*dto.ApplicationInfoDTO, len(*appInfos))
-	for i := range *appInfos {
-		ai := &(*appInfos)[i]
-		appByPath[ai.JarPath] = ai
+	target, status := branch, dto.FeatureBranchSwitched
+	exists, remote, err := s.gitService.FindBranch(gitPath, branch)
+	if err != nil {
+		return finish("", dto.FeatureBranchFailed, err)
 	}
 
-	for _, procInfo := range processes {
-		if ai, ok := appByPath[procInfo.Path]; ok {
-			ai.PID = procInfo.PID
+	if !exists {
+		target = s.defaultBranchFor(apps)
+		if target == "" {
+			return finish(current, dto.FeatureBranchSkipped,
+				fmt.Errorf("ветка %s не найдена, ветка по умолчанию не задана", branch))
+		}
+		status = dto.FeatureBranchFallback
+
+		exists, remote, err = s.gitService.FindBranch(gitPath, target)
+		if err != nil {
+			return finish(current, dto.FeatureBranchFailed, err)
+		}
+		if !exists {
+			return finish(current, dto.FeatureBranchFailed,
+				fmt.Errorf("ветка %s не найдена, ветка по умолчанию %s тоже не найдена", branch, target))
 		}
 	}
 
-	return nil
+	if current == target {
+		return finish(target, status, nil)
+	}
+
+	_, err = s.gitService.CheckoutBranch(gitPath, target, domain.CheckoutOptions{Remote: remote, AutoStash: req.AutoStash})
+
+	for _, ai := range apps {
+		if _, rerr := s.gitStatusMonitor.Refresh(ai.AppName, gitPath); rerr != nil {
+			s.logger.Warn("failed to refresh git status after checkout", "app", ai.AppName, "err", rerr)
+		}
+	}
+
+	if err != nil {
+		return finish(current, dto.FeatureBranchFailed, err)
+	}
+
+	results, _ = finish(target, status, nil)
+	return results, true
+}
+
+func (s *CentralService) defaultBranchFor(apps []*domain.ApplicationInfo) string {
+	for _, ai := range apps {
+		if b := strings.TrimSpace(ai.DefaultBranch); b != "" {
+			return b
+		}
+	}
+	return strings.TrimSpace(s.settingsService.Settings.DefaultGitBranch)
+}
+
+// restartChangedApplications перезапускает уже запущенные приложения из toRestart в порядке StartOrder.
+// С rebuild приложение сначала собирается и перезапускается только после успешной сборки.
+func (s *CentralService) restartChangedApplications(results []dto.FeatureBranchResultDTO, toRestart map[string]bool, rebuild bool) {
+	processes, err := s.GetRunningProcesses()
+	if err != nil {
+		s.logger.Error("failed to list running processes for restart", "err", err)
+		return
+	}
+	running := make(map[string]bool, len(processes))
+	for _, p := range processes {
+		running[p.Name] = true
+	}
+
+	var appNames []string
+	for _, ai := range s.snapshot().ApplicationInfos {
+		if toRestart[ai.AppName] && running[ai.AppName] {
+			appNames = append(appNames, ai.AppName)
+		}
+	}
+	if len(appNames) == 0 {
+		return
+	}
+
+	setResult := func(appName string, restarted bool, err error, rebuildRes *dto.RebuildResultDTO) {
+		for i := range results {
+			if results[i].AppName != appName {
+				continue
+			}
+			results[i].Rebuild = rebuildRes
+			results[i].Restarted = restarted
+			if err != nil {
+				results[i].Error = fmt.Sprintf("перезапуск не удался: %v", err)
+			}
+		}
+	}
+
+	if rebuild {
+		// сборки идут параллельно через очередь, перезапуск — по StartOrder
+		rebuilt, err := s.RebuildAndRestartAll(appNames)
+		if err != nil {
+			for _, appName := range appNames {
+				setResult(appName, false, err, nil)
+			}
+			return
+		}
+		for i := range rebuilt {
+			r := &rebuilt[i]
+			var rerr error
+			if r.Error != "" {
+				rerr = errors.New(r.Error)
+			}
+			setResult(r.AppName, r.Status == dto.RebuildRestarted, rerr, r)
+		}
+		return
+	}
+
+	for _, appName := range appNames {
+		err := s.restartApplication(appName)
+		setResult(appName, err == nil, err, nil)
+	}
+}
+
+// restartApplication останавливает приложение, дожидается завершения процесса и запускает заново.
+func (s *CentralService) restartApplication(appName string) error {
+	if err := s.StopApplication(appName); err != nil {
+		return err
+	}
+	if err := s.waitStopped(appName, 15*time.Second); err != nil {
+		return err
+	}
+	_, err := s.RunApplication(appName)
+	return err
+}
+
+func (s *CentralService) waitStopped(appName string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		stillRunning, err := s.isRunning(appName)
+		if err != nil {
+			return err
+		}
+		if !stillRunning {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("приложение %s не остановилось за %s", appName, timeout)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+func (s *CentralService) isRunning(appName string) (bool, error) {
+	pid, err := s.runningPID(appName)
+	return pid != 0, err
+}
+
+// runningPID - PID запущенного Java процесса приложения (0, если не запущено).
+func (s *CentralService) runningPID(appName string) (int, error) {
+	processes, err := s.GetRunningProcesses()
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range processes {
+		if p.Name == appName {
+			return p.PID, nil
+		}
+	}
+	return 0, nil
+}
+
+// waitReady ждёт готовности только что запущенного приложения: процесс виден в списке Java процессов и
+// - порт приложения (см. util.ServerPort) слушает именно этот процесс, если порт задан;
+// - иначе в логе (quiet mode) есть строка Spring Boot "Started ... in ... seconds";
+// - иначе процесс проработал readyGracePeriod.
+// Завершение процесса или "APPLICATION FAILED TO START" в логе — ошибка.
+func (s *CentralService) waitReady(appInfo *domain.ApplicationInfo, timeout time.Duration) error {
+	var logPath string
+	if s.settingsService.Settings.StartQuietMode {
+		logsDir, err := util.LogsDir()
+		if err != nil {
+			return err
+		}
+		logPath = filepath.Join(logsDir, util.GetLogFileName(appInfo.AppName))
+	}
+
+	port := util.ServerPort(appInfo)
+	deadline := time.Now().Add(timeout)
+	var startedAt time.Time
+	for {
+		pid, err := s.runningPID(appInfo.AppName)
+		if err != nil {
+			return err
+		}
+		running := pid != 0
+
+		switch {
+		case running && startedAt.IsZero():
+			startedAt = time.Now()
+		case !running && !startedAt.IsZero():
+			return fmt.Errorf("приложение %s завершилось во время запуска", appInfo.AppName)
+		}
+
+		if running {
+			logReady, logFailed := false, false
+			if logPath != "" {
+				logReady, logFailed = util.LogReadiness(logPath)
+			}
+			if logFailed {
+				return fmt.Errorf("приложение %s не запустилось: APPLICATION FAILED TO START", appInfo.AppName)
+			}
+
+			switch {
+			case port > 0:
+				listened, err := util.IsPortListenedBy(port, pid)
+				if err != nil {
+					return err
+				}
+				if listened {
+					return nil
+				}
+			case logPath != "":
+				if logReady {
+					return nil
+				}
+			case time.Since(startedAt) >= readyGracePeriod:
+				return nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("приложение %s не готово за %s", appInfo.AppName, timeout)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// RebuildAndRestart собирает приложение и только после успешной сборки останавливает
+// запущенный экземпляр, запускает новый jar и ждёт готовности (см. waitReady).
+// Не запущенное приложение только собирается.
+func (s *CentralService) RebuildAndRestart(appName string) (*dto.RebuildResultDTO, error) {
+	s.logger.Info("execute rebuild and restart", "app", appName)
+	if _, err := s.getAppInfoByName(appName); err != nil {
+		return nil, err
+	}
+
+	res := &dto.RebuildResultDTO{AppName: appName}
+	if s.buildForRestart(res) {
+		s.restartBuilt(res)
+	}
+	return res, nil
+}
+
+// RebuildAndRestartAll собирает приложения параллельно через очередь сборок (не больше BuildMaxParallel)
+// и затем перезапускает собранные по StartOrder, дожидаясь готовности каждого. Перезапускаются только
+// запущенные приложения. Если приложение не запустилось, следующие за ним не перезапускаются.
+// Пустой appNames — все активные приложения с Maven или Gradle.
+func (s *CentralService) RebuildAndRestartAll(appNames []string) ([]dto.RebuildResultDTO, error) {
+	if !s.rebuildAllInProgress.CompareAndSwap(false, true) {
+		return nil, errors.New("пересборка всех приложений уже выполняется")
+	}
+	defer s.rebuildAllInProgress.Store(false)
+
+	s.logger.Info("execute rebuild and restart all", "apps", appNames)
+
+	selected := make(map[string]bool, len(appNames))
+	for _, appName := range appNames {
+		if _, err := s.getAppInfoByName(appName); err != nil {
+			return nil, err
+		}
+		selected[appName] = true
+	}
+
+	// centralInfo отсортирован по StartOrder
+	results := make([]dto.RebuildResultDTO, 0)
+	for _, ai := range s.snapshot().ApplicationInfos {
+		if len(selected) > 0 && !selected[ai.AppName] {
+			continue
+		}
+		if len(selected) == 0 && (!ai.IsActive || (!ai.HasMaven && !ai.HasGradle)) {
+			continue
+		}
+		results = append(results, dto.RebuildResultDTO{AppName: ai.AppName})
+	}
+
+	built := make([]bool, len(results))
+	// все сборки сразу встают в очередь BuildService, она и ограничивает параллельность
+	runParallel(len(results), len(results), func(i int) {
+		built[i] = s.buildForRestart(&results[i])
+	})
+
+	failedApp := ""
+	for i := range results {
+		if !built[i] {
+			continue
+		}
+		if failedApp != "" {
+			results[i].Status = dto.RebuildSkipped
+			results[i].Error = fmt.Sprintf("не перезапущено: не запустилось приложение %s", failedApp)
+			s.emitRebuildProgress(&results[i])
+			continue
+		}
+		if !s.restartBuilt(&results[i]) {
+			failedApp = results[i].AppName
+		}
+	}
+	return results, nil
+}
+
+func (s *CentralService) buildForRestart(res *dto.RebuildResultDTO) bool {
+	res.Status = dto.RebuildBuilding
+	s.emitRebuildProgress(res)
+
+	build, err := s.BuildApplication(res.AppName)
+	res.Build = build
+	switch {
+	case err != nil:
+		res.Error = err.Error()
+	case build.Status != domain.BuildStatusSuccess:
+		res.Error = build.Error
+	default:
+		return true
+	}
+
+	res.Status = dto.RebuildBuildFailed
+	s.emitRebuildProgress(res)
+	return false
+}
+
+// restartBuilt перезапускает собранное приложение и ждёт готовности, если оно запущено.
+func (s *CentralService) restartBuilt(res *dto.RebuildResultDTO) bool {
+	running, err := s.isRunning(res.AppName)
+	if err == nil && !running {
+		res.Status = dto.RebuildBuilt
+		s.emitRebuildProgress(res)
+		return true
+	}
+
+	res.Status = dto.RebuildRestarting
+	s.emitRebuildProgress(res)
+
+	if err == nil {
+		err = s.restartAndWaitReady(res.AppName)
+	}
+	if err != nil {
+		res.Status = dto.RebuildStartFailed
+		res.Error = err.Error()
+	} else {
+		res.Status = dto.RebuildRestarted
+		res.Ready = true
+	}
+	s.emitRebuildProgress(res)
+	return err == nil
+}
+
+func (s *CentralService) restartAndWaitReady(appName string) error {
+	if err := s.restartApplication(appName); err != nil {
+		return err
+	}
+	// RunApplication мог сохранить новый JarPath (см. resolveJarPath)
+	appInfo, err := s.getAppInfoByName(appName)
+	if err != nil {
+		return err
+	}
+	timeout := time.Duration(s.settingsService.Settings.ReadinessTimeoutSec) * time.Second
+	if timeout <= 0 {
+		timeout = defaultReadinessTimeout
+	}
+	return s.waitReady(appInfo, timeout)
+}
+
+func (s *CentralService) emitRebuildProgress(res *dto.RebuildResultDTO) {
+	runtime.EventsEmit(s.ctx, rebuildProgressEventKey, *res)
+}
+
+func (s *CentralService) GetCommitLog(appName string, rev string, skip int, limit int) (*domain.CommitPage, error) {
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return nil, err
+	}
+	return s.gitService.Log(repoPath(appInfo), rev, skip, limit)
+}
+
+func (s *CentralService) GetIncomingChanges(appName string, skip int, limit int) (*domain.CommitPage, error) {
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return nil, err
+	}
+	return s.gitService.IncomingChanges(repoPath(appInfo), skip, limit)
+}
+
+func (s *CentralService) GetCommitDetails(appName string, hash string) (*domain.CommitDetails, error) {
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return nil, err
+	}
+	return s.gitService.CommitDetails(repoPath(appInfo), hash)
+}
+
+func (s *CentralService) GetIncomingFiles(appName string) ([]domain.ChangedFile, error) {
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return nil, err
+	}
+	return s.gitService.RangeChangedFiles(repoPath(appInfo), "HEAD", "@{u}")
+}
+
+func (s *CentralService) GetFileDiff(appName string, rev string, path string) (string, error) {
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return "", err
+	}
+	return s.gitService.FileDiff(repoPath(appInfo), rev, path)
+}
+
+func (s *CentralService) ListStashes(appName string) ([]domain.StashEntry, error) {
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return nil, err
+	}
+	return s.gitService.ListStashes(repoPath(appInfo))
+}
+
+func (s *CentralService) ApplyStash(appName string, hash string) ([]string, error) {
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return nil, err
+	}
+	return s.gitService.ApplyStash(repoPath(appInfo), hash)
+}
+
+func (s *CentralService) DropStash(appName string, hash string) error {
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return err
+	}
+	return s.gitService.DropStash(repoPath(appInfo), hash)
+}
+
+func (s *CentralService) CreateBranch(appName string, opts domain.CreateBranchOptions) error {
+	s.logger.Info("execute git create branch", "app", appName, "branch", opts.Name, "from", opts.StartRef)
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return err
+	}
+	err = s.gitService.CreateBranch(repoPath(appInfo), opts)
+	s.refreshGitStatus(appInfo)
+	return err
+}
+
+func (s *CentralService) DeleteBranch(appName string, branch string) error {
+	s.logger.Info("execute git delete branch", "app", appName, "branch", branch)
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return err
+	}
+	return s.gitService.DeleteBranch(repoPath(appInfo), branch)
+}
+
+func (s *CentralService) ListMergedBranches(appName string) ([]string, error) {
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return nil, err
+	}
+	return s.gitService.ListMergedBranches(repoPath(appInfo))
+}
+
+func (s *CentralService) PruneGoneBranches(appName string, dryRun bool) (*domain.PruneResult, error) {
+	s.logger.Info("execute git prune gone branches", "app", appName, "dryRun", dryRun)
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.gitService.PruneGoneBranches(repoPath(appInfo), dryRun)
+	if err != nil {
+		return nil, err
+	}
+	s.refreshGitStatus(appInfo)
+	return res, nil
+}
+
+func (s *CentralService) ListTags(appName string) ([]string, error) {
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return nil, err
+	}
+	return s.gitService.ListTags(repoPath(appInfo))
+}
+
+func (s *CentralService) CheckoutTag(appName string, tag string) error {
+	s.logger.Info("execute git checkout tag", "app", appName, "tag", tag)
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return err
+	}
+	if err := s.gitService.CheckoutTag(repoPath(appInfo), tag); err != nil {
+		return err
+	}
+	s.refreshGitStatus(appInfo)
+	return nil
+}
+
+// refreshGitStatus обновляет статус приложения и всех приложений из того же репозитория.
+func (s *CentralService) refreshGitStatus(appInfo *domain.ApplicationInfo) {
+	for _, appName := range append([]string{appInfo.AppName}, s.sharedRepoApps(appInfo)...) {
+		s.jarStatusChecker.Invalidate(appName)
+		if _, err := s.gitStatusMonitor.Refresh(appName, repoPath(appInfo)); err != nil {
+			s.logger.Warn("failed to refresh git status", "app", appName, "err", err)
+		}
+	}
+}
+
+// sharedRepoApps - остальные приложения с тем же Git репозиторием, что и у appInfo.
+func (s *CentralService) sharedRepoApps(appInfo *domain.ApplicationInfo) []string {
+	if !appInfo.HasGit {
+		return nil
+	}
+	key := filepath.Clean(repoPath(appInfo))
+
+	var res []string
+	for _, ai := range s.snapshot().ApplicationInfos {
+		if ai.HasGit && ai.AppName != appInfo.AppName && filepath.Clean(repoPath(&ai)) == key {
+			res = append(res, ai.AppName)
+		}
+	}
+	return res
+}
+
+// resolveRepository находит Git репозиторий, в котором лежит BaseDir приложения.
+func (s *CentralService) resolveRepository(appInfo *domain.ApplicationInfo) {
+	root, err := s.gitService.RepoRoot(appInfo.BaseDir)
+	if err != nil {
+		s.logger.Error("Failed to resolve git repository", "err", err, "app", appInfo.AppName)
+	}
+	appInfo.GitRoot = root
+	appInfo.HasGit = root != ""
+}
+
+// repoPath - путь для git команд приложения: корень репозитория, если он известен.
+func repoPath(appInfo *domain.ApplicationInfo) string {
+	if appInfo.GitRoot != "" {
+		return appInfo.GitRoot
+	}
+	return appInfo.BaseDir
+}
+
+func (s *CentralService) PullRepository(appName string, rebase bool) (*domain.PullResult, error) {
+	s.logger.Info("execute git pull", "app", appName, "rebase", rebase)
+	appInfo, err := s.getAppInfoByName(appName)
+	if err != nil {
+		return nil, err
+	}
+	if !appInfo.HasGit {
+		return nil, fmt.Errorf("не указан Git репозиторий для приложения %s", appName)
+	}
+
+	res, err := s.gitService.Pull(repoPath(appInfo), rebase)
+	if err != nil {
+		return nil, err
+	}
+
+	s.refreshGitStatus(appInfo)
+	return res, nil
+}
+
+// UpdateAllRepositories обновляет репозитории всех приложений с Git, не больше
+// GitMaxParallel одновременно. Приложения с общим репозиторием обновляются один раз.
+func (s *CentralService) UpdateAllRepositories(rebase bool) []dto.RepoUpdateResultDTO {
+	s.logger.Info("execute update all repositories", "rebase", rebase)
+
+	results := make([]dto.RepoUpdateResultDTO, 0)
+	indexByPath := make(map[string]int)
+	for _, ref := range s.gitRepoRefs() {
+		key := filepath.Clean(ref.GitPath)
+		if i, ok := indexByPath[key]; ok {
+			results[i].AppNames = append(results[i].AppNames, ref.AppName)
+			continue
+		}
+		indexByPath[key] = len(results)
+		results = append(results, dto.RepoUpdateResultDTO{
+			AppNames: []string{ref.AppName},
+			GitPath:  ref.GitPath,
+		})
+	}
+
+	runParallel(int(s.settingsService.Settings.GitMaxParallel), len(results), func(i int) {
+		r := &results[i]
+		res, err := s.gitService.Pull(r.GitPath, rebase)
+		if err != nil {
+			r.Error = err.Error()
+			s.logger.Error("repository update failed", "path", r.GitPath, "err", err)
+			return
+		}
+		r.Result = res
+
+		for _, appName := range r.AppNames {
+			if _, err := s.gitStatusMonitor.Refresh(appName, r.GitPath); err != nil {
+				s.logger.Warn("failed to refresh git status after pull", "app", appName, "err", err)
+			}
+		}
+	})
+
+	return results
+}
+
+// BuildApplication собирает приложение и ждёт окончания сборки.
+// Если JarPath не задан, в него сохраняется найденный после сборки jar.
+func (s *CentralService) BuildApplication(appName string) (*domain.BuildResult, error) {
+	s.logger.Info("execute build application", "app", appName)
+	appInfo, err := s.getAppInfoByName(appName)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.buildService.Build(appInfo)
+	if err != nil {
+		return nil, err
+	}
+	s.jarStatusChecker.Invalidate(appName)
+
+	if res.Status == domain.BuildStatusSuccess && strings.TrimSpace(appInfo.JarPath) == "" {
+		_, err := s.updateAppInfo(appName, func(ai *domain.ApplicationInfo) {
+			if strings.TrimSpace(ai.JarPath) == "" {
+				ai.JarPath = res.JarPath
+			}
+		})
+		if err != nil {
+			return res, err
+		}
+		s.logger.Info("jar path discovered after build", "app", appName, "jar", res.JarPath)
+	}
+	return res, nil
+}
+
+func (s *CentralService) CancelBuild(appName string) bool {
+	s.logger.Info("execute cancel build", "app", appName)
+	return s.buildService.Cancel(appName)
+}
+
+func (s *CentralService) GetBuildResult(appName string) *domain.BuildResult {
+	return s.buildService.LastResult(appName)
+}
+
+func (s *CentralService) GetBuildQueue() []domain.BuildQueueItem {
+	return s.buildService.Queue()
+}
+
+func (s *CentralService) GetBuildHistory(appName string) []domain.BuildResult {
+	return s.buildService.History(appName)
+}
+
+func (s *CentralService) GetBuildLog(buildID string) (string, error) {
+	return s.buildService.BuildLog(buildID)
+}
+
+// RunTests запускает тесты приложения и ждёт окончания; integration - вместе с интеграционными.
+func (s *CentralService) RunTests(appName string, integration bool) (*domain.TestRunResult, error) {
+	s.logger.Info("execute run tests", "app", appName, "integration", integration)
+	appInfo, err := s.getAppInfoByName(appName)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildService.RunTests(appInfo, integration)
+}
+
+func (s *CentralService) GetTestResult(appName string) *domain.TestRunResult {
+	return s.buildService.LastTestResult(appName)
+}
+
+// GetJarInfo читает метаданные jar приложения: версию, время сборки, коммит и требуемую версию Java.
+func (s *CentralService) GetJarInfo(appName string) (*domain.JarInfo, error) {
+	appInfo, err := s.getAppInfoByName(appName)
+	if err != nil {
+		return nil, err
+	}
+	appInfo = s.withResolvedJar(appInfo)
+	if strings.TrimSpace(appInfo.JarPath) == "" {
+		return nil, fmt.Errorf("у приложения %s не задан JarPath", appName)
+	}
+	return util.ReadJarInfo(appInfo.JarPath)
+}
+
+// GetJarStatus проверяет, не устарел ли jar приложения относительно исходников.
+func (s *CentralService) GetJarStatus(appName string) (*domain.JarStatus, error) {
+	appInfo, err := s.getAppInfoByName(appName)
+	if err != nil {
+		return nil, err
+	}
+	return s.jarStatusChecker.Check(s.withResolvedJar(appInfo)), nil
+}
+
+// CancelGitOperation прерывает выполняющиеся git команды в репозитории приложения.
+func (s *CentralService) CancelGitOperation(appName string) (int, error) {
+	appInfo, err := s.getGitAppInfo(appName)
+	if err != nil {
+		return 0, err
+	}
+	n := s.gitService.Cancel(repoPath(appInfo))
+	s.logger.Info("git operations canceled", "app", appName, "count", n)
+	return n, nil
+}
+
+func (s *CentralService) CancelAllGitOperations() int {
+	n := s.gitService.CancelAll()
+	s.logger.Info("all git operations canceled", "count", n)
+	return n
+}
+
+func (s *CentralService) GetGitStatus(appName string) (*domain.RepoStatus, error) {
+	appInfo, err := s.getAppInfoByName(appName)
+	if err != nil {
+		return nil, err
+	}
+	if !appInfo.HasGit {
+		return nil, fmt.Errorf("отсутствует Git репозиторий для приложения %s", appName)
+	}
+	return s.gitStatusMonitor.Refresh(appInfo.AppName, repoPath(appInfo))
+}
+
+// jarApps - приложения для фоновой проверки jar (см. JarStatusChecker) с jar, найденным по JarPattern.
+func (s *CentralService) jarApps() []domain.ApplicationInfo {
+	apps := slices.Clone(s.snapshot().ApplicationInfos)
+	for i := range apps {
+		apps[i] = *s.withResolvedJar(&apps[i])
+	}
+	return apps
+}
+
+func (s *CentralService) gitRepoRefs() []GitRepoRef {
+	apps := s.snapshot().ApplicationInfos
+	refs := make([]GitRepoRef, 0, len(apps))
+	for _, ai := range apps {
+		if ai.HasGit {
+			refs = append(refs, GitRepoRef{AppName: ai.AppName, GitPath: repoPath(&ai)})
+		}
+	}
+	return refs
+}
+
+func (s *CentralService) setPIDInfo(appInfos *[]dto.ApplicationInfoDTO) error {
+	processes, err := util.ListJavaProcesses()
+	if err != nil {
+		return fmt.Errorf("не удалось получить список запущенных Java процессов")
+	}
+
+	appByPath := make(map[string]*dto.ApplicationInfoDTO, len(*appInfos))
+	for i := range *appInfos {
+		ai := &(*appInfos)[i]
+		appByPath[ai.JarPath] = ai
+	}
+
+	for _, procInfo := range processes {
+		if ai, ok := appByPath[procInfo.Path]; ok {
+			ai.PID = procInfo.PID
+		}
+	}
+
+	return nil
+}
+
+func (s *CentralService) getGitAppInfo(appName string) (*domain.ApplicationInfo, error) {
+	appInfo, err := s.getAppInfoByName(appName)
+	if err != nil {
+		return nil, err
+	}
+	if !appInfo.HasGit {
+		return nil, fmt.Errorf("не указан Git репозиторий для приложения %s", appName)
+	}
+	return appInfo, nil
+}
+
+// snapshot - текущая конфигурация; её нельзя изменять на месте (см. CentralService).
+func (s *CentralService) snapshot() *domain.CentralInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.centralInfo
+}
+
+// updateAppInfo применяет fn к копии описания приложения, сохраняет central-info.json
+// и публикует новую конфигурацию. Возвращает обновлённое описание.
+func (s *CentralService) updateAppInfo(appName string, fn func(ai *domain.ApplicationInfo)) (*domain.ApplicationInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info := *s.centralInfo
+	info.ApplicationInfos = slices.Clone(s.centralInfo.ApplicationInfos)
+	i := slices.IndexFunc(info.ApplicationInfos, func(ai domain.ApplicationInfo) bool {
+		return ai.AppName == appName
+	})
+	if i < 0 {
+		return nil, fmt.Errorf("cannot find application %s", appName)
+	}
+	fn(&info.ApplicationInfos[i])
+
+	if err := util.WriteJSON(util.BuildCentralInfoFilePath(s.settingsService.Settings.CentralInfoPath), &info); err != nil {
+		return nil, err
+	}
+	s.centralInfo = &info
+	return &info.ApplicationInfos[i], nil
 }
 
 func (s *CentralService) getAppInfoByName(appName string) (*domain.ApplicationInfo, error) {
+	ci := s.snapshot()
 	var found *domain.ApplicationInfo
-	for i := range s.centralInfo.ApplicationInfos {
-		if s.centralInfo.ApplicationInfos[i].AppName == appName {
-			found = &s.centralInfo.ApplicationInfos[i]
+	for i := range ci.ApplicationInfos {
+		if ci.ApplicationInfos[i].AppName == appName {
+			found = &ci.ApplicationInfos[i]
 			break
 		}
 	}
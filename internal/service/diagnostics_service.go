@@ -0,0 +1,213 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"central-desktop/internal/domain"
+	"central-desktop/internal/dto"
+	"central-desktop/internal/util"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type DiagnosticsService struct {
+	logger     *slog.Logger
+	ctx        context.Context
+	gitService *GitService
+}
+
+func NewDiagnosticsService(lg *slog.Logger, gs *GitService, ctx context.Context) *DiagnosticsService {
+	lg.Info("Initializing diagnostics service")
+	return &DiagnosticsService{logger: lg, gitService: gs, ctx: ctx}
+}
+
+// Export собирает zip-архив: логи приложений (текущий и предыдущий запуск), команда запуска
+// и переменные окружения со скрытыми секретами, версия JDK, ветка/коммит, app.log и settings.json JAC.
+// Всё описывается в manifest.json. Недоступные файлы не прерывают экспорт, а попадают в manifest.Errors.
+// Если архив записать не удалось, недописанный файл удаляется.
+func (s *DiagnosticsService) Export(archivePath string, apps []*domain.ApplicationInfo, globalVars []domain.EnvVariable) (err error) {
+	if strings.TrimSpace(archivePath) == "" {
+		return fmt.Errorf("archive path is empty")
+	}
+
+	logsDir, err := util.LogsDir()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(archivePath)
+	if err != nil {
+		return fmt.Errorf("не удалось создать архив %s: %w", archivePath, err)
+	}
+	defer func() {
+		_ = out.Close()
+		if err != nil {
+			if rerr := os.Remove(archivePath); rerr != nil && !os.IsNotExist(rerr) {
+				s.logger.Warn("failed to remove partial diagnostics archive", "path", archivePath, "err", rerr)
+			}
+		}
+	}()
+
+	zw := zip.NewWriter(out)
+
+	manifest := dto.DiagnosticsManifestDTO{
+		CreatedAt:    time.Now(),
+		GlobalEnv:    util.RedactEnv(globalVars),
+		Applications: make([]dto.DiagnosticsAppDTO, 0, len(apps)),
+	}
+
+	addFile := func(srcPath string, name string) {
+		if err := addFileToZip(zw, srcPath, name); err != nil {
+			if !os.IsNotExist(err) {
+				manifest.Errors = append(manifest.Errors, fmt.Sprintf("%s: %v", name, err))
+			}
+			return
+		}
+		manifest.Files = append(manifest.Files, name)
+	}
+
+	javaVersion, err := util.JavaVersion()
+	if err != nil {
+		manifest.Errors = append(manifest.Errors, err.Error())
+	}
+	manifest.JavaVersion = javaVersion
+
+	// JAC пишет в app.log параметры запуска, поэтому лог попадает в архив со скрытыми секретами
+	if err := addRedactedFileToZip(zw, filepath.Join(logsDir, "app.log"), "jac/app.log"); err != nil {
+		if !os.IsNotExist(err) {
+			manifest.Errors = append(manifest.Errors, fmt.Sprintf("jac/app.log: %v", err))
+		}
+	} else {
+		manifest.Files = append(manifest.Files, "jac/app.log")
+	}
+	if settingsPath, err := util.SettingsFilePath(); err == nil {
+		addFile(settingsPath, "jac/settings.json")
+	}
+
+	for _, ai := range apps {
+		appDTO := dto.DiagnosticsAppDTO{
+			AppName:     ai.AppName,
+			BaseDir:     ai.BaseDir,
+			JarPath:     ai.JarPath,
+			CommandLine: util.RedactArgs(util.JavaCommandLine(ai)),
+			Env:         util.RedactEnv(ai.EnvVariables),
+		}
+
+		if ai.HasGit {
+			branch, commit, err := s.gitService.CurrentRevision(ai.BaseDir)
+			if err != nil {
+				manifest.Errors = append(manifest.Errors, fmt.Sprintf("%s: git: %v", ai.AppName, err))
+			}
+			appDTO.GitBranch = branch
+			appDTO.GitCommit = commit
+		}
+
+		dir := "apps/" + sanitizeArchiveName(ai.AppName)
+		addFile(filepath.Join(logsDir, util.GetLogFileName(ai.AppName)), dir+"/current.log")
+		addFile(filepath.Join(logsDir, util.GetPrevLogFileName(ai.AppName)), dir+"/previous.log")
+
+		manifest.Applications = append(manifest.Applications, appDTO)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	w, err := zw.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("не удалось записать архив %s: %w", archivePath, err)
+	}
+
+	s.logger.Info("diagnostics exported", "path", archivePath, "apps", len(apps), "errors", len(manifest.Errors))
+	return nil
+}
+
+func addFileToZip(zw *zip.Writer, srcPath string, name string) error {
+	f, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	st, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(st)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// addRedactedFileToZip добавляет текстовый файл построчно, скрывая секреты (см. util.RedactLogLine).
+func addRedactedFileToZip(zw *zip.Writer, srcPath string, name string) error {
+	f, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	st, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(st)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		if _, err := io.WriteString(w, util.RedactLogLine(sc.Text())+"\n"); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+func sanitizeArchiveName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, name)
+}
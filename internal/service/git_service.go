@@ -3,22 +3,51 @@ package service
 import (
 	"bytes"
 	"central-desktop/internal/domain"
+	"central-desktop/internal/util"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	defaultGitTimeout   = 2 * time.Minute
+	gitWaitDelay        = 5 * time.Second
+	gitProgressEventKey = "git:progress"
 )
 
+// GitService выполняет git команды с таймаутом и возможностью отмены.
+// События:
+// - "git:progress" -> payload: domain.GitProgress (fetch, checkout, push)
 type GitService struct {
-	ctx    context.Context
-	logger *slog.Logger
+	ctx             context.Context
+	logger          *slog.Logger
+	settingsService *SettingsService
+	mu              sync.Mutex
+	running         map[string]map[uint64]context.CancelFunc
+	nextCmdID       uint64
 }
 
-func NewGitService(lg *slog.Logger, ctx context.Context) *GitService {
+func NewGitService(lg *slog.Logger, ss *SettingsService, ctx context.Context) *GitService {
 	lg.Info("Initializing git service")
-	return &GitService{ctx: ctx, logger: lg}
+	return &GitService{
+		ctx:             ctx,
+		logger:          lg,
+		settingsService: ss,
+		running:         make(map[string]map[uint64]context.CancelFunc),
+	}
 }
 
 func (s *GitService) ListBranches(gitPath string, fetch bool) (*domain.Branches, error) {
@@ -61,60 +90,1025 @@ func (s *GitService) ListBranches(gitPath string, fetch bool) (*domain.Branches,
 
 		result.Remote = remote
 	}
+	{
+		remotes, err := s.ListRemotes(gitPath)
+		if err != nil {
+			return nil, err
+		}
+		result.Remotes = remotes
+	}
 
 	return result, nil
 }
 
-func (s *GitService) CheckoutBranch(gitPath string, branch string) error {
+// CheckoutBranch переключает репозиторий на ветку. branch может быть локальной веткой
+// или "<remote>/<ветка>" для любого remote. Если локальной ветки нет, создаётся
+// tracking-ветка; когда ветка есть на нескольких remote, нужно указать opts.Remote —
+// иначе возвращается ошибка и список CandidateRemotes.
+// Если рабочее дерево не чистое: без opts.AutoStash — ошибка; с AutoStash изменения
+// (включая untracked) прячутся в stash, а после переключения применяются обратно.
+// При конфликтах stash не удаляется, а конфликтующие файлы возвращаются вместе с ошибкой.
+func (s *GitService) CheckoutBranch(gitPath string, branch string, opts domain.CheckoutOptions) (*domain.CheckoutResult, error) {
 	branch = strings.TrimSpace(branch)
 	if branch == "" {
-		return fmt.Errorf("branch is empty")
+		return nil, fmt.Errorf("branch is empty")
+	}
+
+	localBranch, trackingRef, candidates, err := s.resolveCheckoutTarget(gitPath, branch, strings.TrimSpace(opts.Remote))
+	if err != nil {
+		return &domain.CheckoutResult{Branch: branch, CandidateRemotes: candidates}, err
+	}
+
+	result := &domain.CheckoutResult{
+		Branch:           localBranch,
+		TrackingRef:      trackingRef,
+		CandidateRemotes: candidates,
 	}
 
 	clean, err := s.isWorkingTreeClean(gitPath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !clean {
-		return fmt.Errorf("working tree is not clean: commit or stash changes before switching branch")
+		if !opts.AutoStash {
+			return nil, fmt.Errorf("working tree is not clean: commit or stash changes before switching branch")
+		}
+
+		hash, err := s.stashPush(gitPath, localBranch)
+		if err != nil {
+			return nil, err
+		}
+		result.Stashed = true
+		result.StashHash = hash
 	}
 
-	// Если в UI прилетело "origin/master" — переключаем на "master"
-	localBranch := branch
-	if strings.HasPrefix(localBranch, "origin/") {
-		localBranch = strings.TrimPrefix(localBranch, "origin/")
+	if err := s.switchBranch(gitPath, localBranch, trackingRef); err != nil {
+		if result.Stashed {
+			// переключиться не удалось — возвращаем изменения на место
+			if restoreErr := s.applyStash(gitPath, result.StashHash, true); restoreErr != nil {
+				return result, fmt.Errorf("%w (changes are kept in stash %s: %v)", err, result.StashHash, restoreErr)
+			}
+		}
+		return nil, err
 	}
 
-	// 1) Попробовать обычный switch (если локальная ветка уже есть)
-	if _, err := s.runGit(gitPath, "switch", localBranch); err == nil {
+	if !result.Stashed {
+		return result, nil
+	}
+
+	if err := s.applyStash(gitPath, result.StashHash, true); err != nil {
+		conflicts, _ := s.conflictedFiles(gitPath)
+		result.Conflicts = conflicts
+		return result, fmt.Errorf("branch switched to %s, but stashed changes could not be applied: %w (stash %s is kept)",
+			localBranch, err, result.StashHash)
+	}
+	result.Restored = true
+
+	return result, nil
+}
+
+// FindBranch проверяет, есть ли ветка локально или на каком-либо remote.
+// Для ветки, которая есть только на remote, возвращается remote для отслеживания
+// (origin, если ветка есть на нескольких remote и среди них есть origin).
+func (s *GitService) FindBranch(gitPath string, branch string) (bool, string, error) {
+	hasLocal, err := s.hasLocalBranch(gitPath, branch)
+	if err != nil {
+		return false, "", err
+	}
+	if hasLocal {
+		return true, "", nil
+	}
+
+	remotes, err := s.ListRemotes(gitPath)
+	if err != nil {
+		return false, "", err
+	}
+
+	found := ""
+	for _, r := range remotes {
+		hasRemote, err := s.hasRemoteBranch(gitPath, r+"/"+branch)
+		if err != nil {
+			return false, "", err
+		}
+		if !hasRemote {
+			continue
+		}
+		if r == "origin" {
+			return true, r, nil
+		}
+		if found == "" {
+			found = r
+		}
+	}
+	return found != "", found, nil
+}
+
+// CreateBranch создаёт локальную ветку от HEAD или любого ref, при необходимости
+// переключается на неё и публикует на remote с настройкой upstream.
+func (s *GitService) CreateBranch(gitPath string, opts domain.CreateBranchOptions) error {
+	name := strings.TrimSpace(opts.Name)
+	if name == "" {
+		return fmt.Errorf("branch name is empty")
+	}
+	if _, err := s.runGit(gitPath, "check-ref-format", "--branch", name); err != nil {
+		return fmt.Errorf("invalid branch name %s: %w", name, err)
+	}
+
+	startRef := strings.TrimSpace(opts.StartRef)
+	if startRef == "" {
+		startRef = "HEAD"
+	}
+	if err := validateRev(startRef); err != nil {
+		return err
+	}
+
+	if opts.Checkout {
+		if _, err := s.runGit(gitPath, "switch", "--no-track", "-c", name, startRef); err != nil {
+			return err
+		}
+	} else if _, err := s.runGit(gitPath, "branch", "--no-track", name, startRef); err != nil {
+		return err
+	}
+
+	if !opts.Push {
 		return nil
 	}
 
-	// 2) Если локальная ветка есть — повторяем switch и отдаём нормальную ошибку
-	hasLocal, err := s.hasLocalBranch(gitPath, localBranch)
+	remote := strings.TrimSpace(opts.Remote)
+	if remote == "" {
+		remotes, err := s.ListRemotes(gitPath)
+		if err != nil {
+			return err
+		}
+		switch {
+		case slices.Contains(remotes, "origin"):
+			remote = "origin"
+		case len(remotes) > 0:
+			remote = remotes[0]
+		default:
+			return fmt.Errorf("branch %s created, but repository has no remotes to push to", name)
+		}
+	}
+
+	if _, err := s.runGitWithProgress(gitPath, "push", "push", "--progress", "--set-upstream", remote, name); err != nil {
+		return fmt.Errorf("branch %s created, but push to %s failed: %w", name, remote, err)
+	}
+	return nil
+}
+
+// DeleteBranch удаляет локальную ветку, только если она слита (git branch -d)
+// и не является текущей.
+func (s *GitService) DeleteBranch(gitPath string, name string) error {
+	name = strings.TrimSpace(name)
+	if err := validateRev(name); err != nil {
+		return err
+	}
+
+	current, err := s.runGit(gitPath, "branch", "--show-current")
 	if err != nil {
 		return err
 	}
-	if hasLocal {
-		_, err := s.runGit(gitPath, "switch", localBranch)
+	if strings.TrimSpace(current) == name {
+		return fmt.Errorf("cannot delete current branch %s", name)
+	}
+
+	if _, err := s.runGit(gitPath, "branch", "-d", name); err != nil {
+		return fmt.Errorf("branch %s is not deleted: %w", name, err)
+	}
+	return nil
+}
+
+// ListMergedBranches - локальные ветки (кроме текущей), уже слитые в HEAD.
+func (s *GitService) ListMergedBranches(gitPath string) ([]string, error) {
+	out, err := s.runGit(gitPath, "for-each-ref", "--merged", "HEAD", "refs/heads", "--format=%(HEAD)%(refname:short)")
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, 0)
+	for _, line := range splitNonEmptyLines(out) {
+		if strings.HasPrefix(line, "*") {
+			continue
+		}
+		res = append(res, line)
+	}
+	return res, nil
+}
+
+// PruneGoneBranches делает fetch --prune и удаляет локальные ветки, чей upstream
+// исчез на remote. Неслитые ветки и текущая ветка не удаляются, а попадают в Skipped.
+// С dryRun ничего не удаляется — только возвращается список кандидатов в Deleted.
+func (s *GitService) PruneGoneBranches(gitPath string, dryRun bool) (*domain.PruneResult, error) {
+	if err := s.fetch(gitPath); err != nil {
+		return nil, fmt.Errorf("failed to fetch git repository: %w", err)
+	}
+
+	out, err := s.runGit(gitPath, "for-each-ref", "refs/heads", "--format=%(HEAD)%(refname:short)%1f%(upstream:track)")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.PruneResult{Deleted: []string{}, Skipped: []string{}}
+	for _, line := range splitNonEmptyLines(out) {
+		name, track, _ := strings.Cut(line, "\x1f")
+		if track != "[gone]" {
+			continue
+		}
+		if strings.HasPrefix(name, "*") {
+			result.Skipped = append(result.Skipped, strings.TrimPrefix(name, "*")+": current branch")
+			continue
+		}
+		if dryRun {
+			result.Deleted = append(result.Deleted, name)
+			continue
+		}
+		if _, err := s.runGit(gitPath, "branch", "-d", name); err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		result.Deleted = append(result.Deleted, name)
+	}
+	return result, nil
+}
+
+// ListTags возвращает теги, новые — первыми.
+func (s *GitService) ListTags(gitPath string) ([]string, error) {
+	out, err := s.runGit(gitPath, "tag", "--list", "--sort=-creatordate")
+	if err != nil {
+		return nil, err
+	}
+	return splitNonEmptyLines(out), nil
+}
+
+// CheckoutTag переключает репозиторий на тег в состоянии detached HEAD.
+func (s *GitService) CheckoutTag(gitPath string, tag string) error {
+	tag = strings.TrimSpace(tag)
+	if err := validateRev(tag); err != nil {
 		return err
 	}
 
-	// 3) Локальной ветки нет — ищем remote-tracking ветку
-	remoteBranch := "origin/" + localBranch
-	hasRemote, err := s.hasRemoteBranch(gitPath, remoteBranch)
+	if _, err := s.runGit(gitPath, "show-ref", "--verify", "--quiet", "refs/tags/"+tag); err != nil {
+		return fmt.Errorf("tag not found: %s", tag)
+	}
+
+	clean, err := s.isWorkingTreeClean(gitPath)
 	if err != nil {
 		return err
 	}
-	if !hasRemote {
-		return fmt.Errorf("branch not found: %s (no local branch and no %s)", localBranch, remoteBranch)
+	if !clean {
+		return fmt.Errorf("working tree is not clean: commit or stash changes before checking out tag")
 	}
 
-	// 4) Создаём локальную tracking-ветку и переключаемся на неё
-	_, err = s.runGit(gitPath, "switch", "-c", localBranch, "--track", remoteBranch)
+	_, err = s.runGitWithProgress(gitPath, "checkout", "switch", "--progress", "--detach", "refs/tags/"+tag)
 	return err
 }
 
+// ListWorktrees возвращает worktree репозитория; первым идёт основной.
+func (s *GitService) ListWorktrees(gitPath string) ([]domain.Worktree, error) {
+	out, err := s.runGit(gitPath, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	return parseWorktreeList(out), nil
+}
+
+// AddWorktree создаёт worktree в path для ветки branch. Если локальной ветки нет,
+// она создаётся от remote-tracking ветки (как в CheckoutBranch). Возвращает имя локальной ветки.
+func (s *GitService) AddWorktree(gitPath string, path string, branch string) (string, error) {
+	branch = strings.TrimSpace(branch)
+	if err := validateRev(branch); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(path) == "" {
+		return "", fmt.Errorf("worktree path is empty")
+	}
+
+	localBranch, trackingRef, _, err := s.resolveCheckoutTarget(gitPath, branch, "")
+	if err != nil {
+		return "", err
+	}
+
+	if trackingRef == "" {
+		_, err = s.runGit(gitPath, "worktree", "add", path, localBranch)
+	} else {
+		_, err = s.runGit(gitPath, "worktree", "add", "--track", "-b", localBranch, path, trackingRef)
+	}
+	if err != nil {
+		return "", err
+	}
+	return localBranch, nil
+}
+
+// RemoveWorktree удаляет worktree. Без force git откажется удалять worktree с изменениями.
+// Если папки уже нет, только очищаются записи о ней в репозитории.
+func (s *GitService) RemoveWorktree(gitPath string, path string, force bool) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		_, err := s.runGit(gitPath, "worktree", "prune")
+		return err
+	}
+
+	args := []string{"worktree", "remove"}
+	if force {
+		args = append(args, "--force")
+	}
+	_, err := s.runGit(gitPath, append(args, path)...)
+	return err
+}
+
+// parseWorktreeList разбирает вывод "git worktree list --porcelain":
+// блоки атрибутов разделены пустой строкой, первый блок — основной worktree.
+func parseWorktreeList(out string) []domain.Worktree {
+	res := make([]domain.Worktree, 0)
+	var cur *domain.Worktree
+
+	for _, line := range strings.Split(strings.ReplaceAll(out, "\r\n", "\n"), "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "worktree":
+			res = append(res, domain.Worktree{Path: filepath.FromSlash(value), Main: len(res) == 0})
+			cur = &res[len(res)-1]
+		case "HEAD":
+			if cur != nil {
+				cur.Head = value
+			}
+		case "branch":
+			if cur != nil {
+				cur.Branch = strings.TrimPrefix(value, "refs/heads/")
+			}
+		case "detached":
+			if cur != nil {
+				cur.Detached = true
+			}
+		case "locked":
+			if cur != nil {
+				cur.Locked = true
+			}
+		case "prunable":
+			if cur != nil {
+				cur.Prunable = true
+			}
+		}
+	}
+	return res
+}
+
+// ListRemotes возвращает имена remote репозитория.
+func (s *GitService) ListRemotes(gitPath string) ([]string, error) {
+	out, err := s.runGit(gitPath, "remote")
+	if err != nil {
+		return nil, err
+	}
+	return splitNonEmptyLines(out), nil
+}
+
+// resolveCheckoutTarget определяет локальную ветку и, если её ещё нет,
+// remote-tracking ветку, от которой её создать.
+func (s *GitService) resolveCheckoutTarget(gitPath string, branch string, remote string) (string, string, []string, error) {
+	remotes, err := s.ListRemotes(gitPath)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	// Если в UI прилетело "<remote>/master" — переключаем на "master" с этого remote
+	localBranch := branch
+	if prefix, rest, ok := strings.Cut(branch, "/"); ok && slices.Contains(remotes, prefix) {
+		if hasRemote, _ := s.hasRemoteBranch(gitPath, branch); hasRemote {
+			localBranch = rest
+			if remote == "" {
+				remote = prefix
+			}
+		}
+	}
+
+	hasLocal, err := s.hasLocalBranch(gitPath, localBranch)
+	if err != nil {
+		return "", "", nil, err
+	}
+	if hasLocal {
+		return localBranch, "", nil, nil
+	}
+
+	// Локальной ветки нет — ищем remote-tracking ветки на всех remote
+	candidates := make([]string, 0, len(remotes))
+	for _, r := range remotes {
+		hasRemote, err := s.hasRemoteBranch(gitPath, r+"/"+localBranch)
+		if err != nil {
+			return "", "", nil, err
+		}
+		if hasRemote {
+			candidates = append(candidates, r)
+		}
+	}
+
+	switch {
+	case remote != "":
+		if !slices.Contains(candidates, remote) {
+			return "", "", candidates, fmt.Errorf("branch not found: %s (no local branch and no %s/%s)", localBranch, remote, localBranch)
+		}
+	case len(candidates) == 0:
+		return "", "", nil, fmt.Errorf("branch not found: %s (no local branch and no remote branch on %s)",
+			localBranch, strings.Join(remotes, ", "))
+	case len(candidates) > 1:
+		return "", "", candidates, fmt.Errorf("branch %s exists on several remotes (%s): choose remote to track",
+			localBranch, strings.Join(candidates, ", "))
+	default:
+		remote = candidates[0]
+	}
+
+	return localBranch, remote + "/" + localBranch, candidates, nil
+}
+
+// switchBranch переключается на локальную ветку; если задан trackingRef —
+// сначала создаёт её как tracking-ветку.
+func (s *GitService) switchBranch(gitPath string, localBranch string, trackingRef string) error {
+	if trackingRef == "" {
+		_, err := s.runGitWithProgress(gitPath, "checkout", "switch", "--progress", localBranch)
+		return err
+	}
+
+	_, err := s.runGitWithProgress(gitPath, "checkout", "switch", "--progress", "-c", localBranch, "--track", trackingRef)
+	return err
+}
+
+// Pull делает fetch и обновляет текущую ветку до её upstream.
+// По умолчанию допускается только fast-forward; если ветка разошлась с upstream,
+// возвращается ошибка, пока явно не выбран rebase. Неудачный rebase откатывается.
+func (s *GitService) Pull(gitPath string, rebase bool) (*domain.PullResult, error) {
+	if err := s.fetch(gitPath); err != nil {
+		return nil, fmt.Errorf("failed to fetch git repository: %w", err)
+	}
+
+	branch, err := s.runGit(gitPath, "branch", "--show-current")
+	if err != nil {
+		return nil, err
+	}
+	branch = strings.TrimSpace(branch)
+	if branch == "" {
+		return nil, fmt.Errorf("HEAD is detached: nothing to update")
+	}
+
+	upstream, err := s.runGit(gitPath, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}")
+	if err != nil {
+		return nil, fmt.Errorf("branch %s has no upstream: %w", branch, err)
+	}
+
+	result := &domain.PullResult{
+		Branch:   branch,
+		Upstream: strings.TrimSpace(upstream),
+	}
+
+	if result.FromCommit, err = s.revParse(gitPath, "HEAD"); err != nil {
+		return nil, err
+	}
+
+	ahead, behind, err := s.aheadBehind(gitPath, "HEAD", "@{u}")
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case behind == 0:
+		result.ToCommit = result.FromCommit
+		result.UpToDate = true
+		return result, nil
+
+	case ahead == 0:
+		if _, err := s.runGit(gitPath, "merge", "--ff-only", "@{u}"); err != nil {
+			return nil, err
+		}
+
+	case !rebase:
+		return nil, fmt.Errorf("branch %s has diverged from %s (ahead %d, behind %d): fast-forward is not possible, use rebase",
+			branch, result.Upstream, ahead, behind)
+
+	default:
+		if _, err := s.runGit(gitPath, "rebase", "@{u}"); err != nil {
+			_, _ = s.runGit(gitPath, "rebase", "--abort")
+			return nil, fmt.Errorf("rebase onto %s failed and was aborted: %w", result.Upstream, err)
+		}
+		result.Rebased = true
+	}
+
+	if result.ToCommit, err = s.revParse(gitPath, "HEAD"); err != nil {
+		return nil, err
+	}
+	result.Range = result.FromCommit + ".." + result.ToCommit
+	result.Commits = behind
+
+	return result, nil
+}
+
+func (s *GitService) revParse(gitPath string, ref string) (string, error) {
+	out, err := s.runGit(gitPath, "rev-parse", ref)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// aheadBehind считает коммиты, которые есть только в left (ahead) и только в right (behind).
+func (s *GitService) aheadBehind(gitPath string, left string, right string) (int, int, error) {
+	out, err := s.runGit(gitPath, "rev-list", "--left-right", "--count", left+"..."+right)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var ahead, behind int
+	if _, err := fmt.Sscanf(strings.TrimSpace(out), "%d\t%d", &ahead, &behind); err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output %q: %w", out, err)
+	}
+	return ahead, behind, nil
+}
+
+// GetStatus возвращает состояние репозитория: ветку и upstream, ahead/behind
+// (относительно последнего fetch), изменённые файлы и HEAD коммит.
+func (s *GitService) GetStatus(gitPath string) (*domain.RepoStatus, error) {
+	out, err := s.runGit(gitPath, "-c", "core.quotePath=false", "status", "--porcelain=v2", "--branch")
+	if err != nil {
+		return nil, err
+	}
+
+	status := parsePorcelainV2Status(out)
+	status.UpdatedAt = time.Now()
+
+	if status.Detached {
+		status.DetachedAt = s.describeDetachedHead(gitPath)
+	}
+
+	head, err := s.headCommit(gitPath)
+	if err != nil {
+		s.logger.Warn("failed to read HEAD commit", "path", gitPath, "err", err)
+	}
+	status.Head = head
+
+	return status, nil
+}
+
+const defaultCommitPageSize = 50
+
+// Log возвращает страницу истории коммитов. rev — ветка, коммит или диапазон
+// (например "HEAD..origin/master"); пусто — HEAD.
+func (s *GitService) Log(gitPath string, rev string, skip int, limit int) (*domain.CommitPage, error) {
+	rev = strings.TrimSpace(rev)
+	if rev == "" {
+		rev = "HEAD"
+	}
+	if err := validateRev(rev); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultCommitPageSize
+	}
+	if skip < 0 {
+		skip = 0
+	}
+
+	// берём на один коммит больше, чтобы понять, есть ли следующая страница
+	out, err := s.runGit(gitPath, "log", "--format="+gitCommitFormat,
+		"--skip="+strconv.Itoa(skip), "--max-count="+strconv.Itoa(limit+1), rev, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.CommitPage{Commits: make([]domain.GitCommit, 0, limit)}
+	for _, line := range splitNonEmptyLines(out) {
+		if len(page.Commits) == limit {
+			page.HasMore = true
+			break
+		}
+		c, err := parseCommitLine(line)
+		if err != nil {
+			return nil, err
+		}
+		page.Commits = append(page.Commits, *c)
+	}
+	return page, nil
+}
+
+// IncomingChanges - коммиты upstream, которых ещё нет в текущей ветке (HEAD..@{u}).
+func (s *GitService) IncomingChanges(gitPath string, skip int, limit int) (*domain.CommitPage, error) {
+	return s.Log(gitPath, "HEAD..@{u}", skip, limit)
+}
+
+// CommitDetails возвращает коммит с полным сообщением и списком изменённых файлов
+// (для merge-коммита — относительно первого родителя).
+func (s *GitService) CommitDetails(gitPath string, hash string) (*domain.CommitDetails, error) {
+	hash = strings.TrimSpace(hash)
+	if err := validateRev(hash); err != nil {
+		return nil, err
+	}
+
+	out, err := s.runGit(gitPath, "log", "-1", "--format="+gitCommitFormat, hash, "--")
+	if err != nil {
+		return nil, err
+	}
+	commit, err := parseCommitLine(strings.TrimSpace(out))
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := s.runGit(gitPath, "log", "-1", "--format=%b", hash, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := s.changedFiles(gitPath, "show", "--format=", "--diff-merges=first-parent", hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CommitDetails{
+		Commit: *commit,
+		Body:   strings.TrimSpace(body),
+		Files:  files,
+	}, nil
+}
+
+// RangeChangedFiles - файлы, изменённые между from и to (например HEAD и origin/master).
+func (s *GitService) RangeChangedFiles(gitPath string, from string, to string) ([]domain.ChangedFile, error) {
+	if err := validateRev(from); err != nil {
+		return nil, err
+	}
+	if err := validateRev(to); err != nil {
+		return nil, err
+	}
+	return s.changedFiles(gitPath, "diff", from+".."+to)
+}
+
+// FileDiff возвращает unified diff файла. rev — коммит (diff относительно первого родителя)
+// или диапазон "from..to".
+func (s *GitService) FileDiff(gitPath string, rev string, path string) (string, error) {
+	rev = strings.TrimSpace(rev)
+	if err := validateRev(rev); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(path) == "" {
+		return "", fmt.Errorf("file path is empty")
+	}
+
+	if strings.Contains(rev, "..") {
+		return s.runGit(gitPath, "-c", "core.quotePath=false", "diff", "-M", rev, "--", path)
+	}
+	return s.runGit(gitPath, "-c", "core.quotePath=false", "show", "--format=", "-M",
+		"--diff-merges=first-parent", rev, "--", path)
+}
+
+// changedFiles объединяет --name-status и --numstat для одной и той же команды diff/show.
+func (s *GitService) changedFiles(gitPath string, args ...string) ([]domain.ChangedFile, error) {
+	base := append([]string{"-c", "core.quotePath=false"}, args...)
+
+	statusOut, err := s.runGit(gitPath, append(append([]string{}, base...), "-M", "--name-status")...)
+	if err != nil {
+		return nil, err
+	}
+	numstatOut, err := s.runGit(gitPath, append(append([]string{}, base...), "-M", "--numstat")...)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]domain.ChangedFile, 0)
+	for _, line := range splitNonEmptyLines(statusOut) {
+		// M<TAB>path | R100<TAB>old<TAB>new
+		parts := strings.Split(line, "\t")
+		if len(parts) < 2 {
+			continue
+		}
+		f := domain.ChangedFile{Status: parts[0][:1], Path: parts[len(parts)-1]}
+		if len(parts) > 2 {
+			f.OldPath = parts[1]
+		}
+		files = append(files, f)
+	}
+
+	byPath := make(map[string]*domain.ChangedFile, len(files))
+	for i := range files {
+		byPath[files[i].Path] = &files[i]
+	}
+	for _, line := range splitNonEmptyLines(numstatOut) {
+		// add<TAB>del<TAB>path; для переименования path = "old => new" или "dir/{old => new}"
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		f, ok := byPath[renamedNumstatPath(parts[2])]
+		if !ok {
+			continue
+		}
+		if parts[0] == "-" {
+			f.Binary = true
+			continue
+		}
+		f.Additions, _ = strconv.Atoi(parts[0])
+		f.Deletions, _ = strconv.Atoi(parts[1])
+	}
+
+	return files, nil
+}
+
+// renamedNumstatPath возвращает новый путь из записи numstat вида "a => b" или "dir/{a => b}/f".
+func renamedNumstatPath(p string) string {
+	if !strings.Contains(p, " => ") {
+		return p
+	}
+	if open := strings.Index(p, "{"); open >= 0 {
+		if closeIdx := strings.Index(p[open:], "}"); closeIdx >= 0 {
+			inner := p[open+1 : open+closeIdx]
+			_, newPart, _ := strings.Cut(inner, " => ")
+			res := p[:open] + newPart + p[open+closeIdx+1:]
+			return strings.ReplaceAll(res, "//", "/")
+		}
+	}
+	_, newPath, _ := strings.Cut(p, " => ")
+	return newPath
+}
+
+// validateRev не даёт передать в git опцию вместо ревизии.
+func validateRev(rev string) error {
+	if rev == "" {
+		return fmt.Errorf("revision is empty")
+	}
+	if strings.HasPrefix(rev, "-") {
+		return fmt.Errorf("invalid revision: %s", rev)
+	}
+	return nil
+}
+
+// RepoRoot возвращает корень Git репозитория, в котором находится dir: dir может быть
+// подпапкой монорепозитория, worktree или submodule. Пустая строка — dir не в репозитории.
+func (s *GitService) RepoRoot(dir string) (string, error) {
+	if st, err := os.Stat(dir); err != nil || !st.IsDir() {
+		return "", nil
+	}
+
+	ctx, done := s.startCommand(dir)
+	defer done()
+
+	cmd := newGitCmd(ctx, dir, "rev-parse", "--show-toplevel")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	root := strings.TrimSpace(out.String())
+	if root == "" {
+		return "", nil
+	}
+	return filepath.Clean(filepath.FromSlash(root)), nil
+}
+
+// PathChangesSince сравнивает path (папку модуля) в HEAD с коммитом commit.
+// committed - между commit и HEAD есть изменения в path; dirty - в path есть незакоммиченные изменения.
+// known = false, если коммита commit нет в репозитории (например, ещё не сделан fetch).
+func (s *GitService) PathChangesSince(gitPath string, commit string, path string) (committed bool, dirty bool, known bool, err error) {
+	if err := validateRev(commit); err != nil {
+		return false, false, false, err
+	}
+	if _, err := s.runGit(gitPath, "cat-file", "-e", commit+"^{commit}"); err != nil {
+		return false, false, false, nil
+	}
+
+	out, err := s.runGit(gitPath, "diff", "--name-only", commit, "HEAD", "--", path)
+	if err != nil {
+		return false, false, true, err
+	}
+	committed = strings.TrimSpace(out) != ""
+
+	out, err = s.runGit(gitPath, "status", "--porcelain", "--", path)
+	if err != nil {
+		return committed, false, true, err
+	}
+	return committed, strings.TrimSpace(out) != "", true, nil
+}
+
+// CurrentRevision возвращает текущую ветку (пусто при detached HEAD) и хэш HEAD.
+func (s *GitService) CurrentRevision(gitPath string) (string, string, error) {
+	branch, err := s.runGit(gitPath, "branch", "--show-current")
+	if err != nil {
+		return "", "", err
+	}
+	commit, err := s.runGit(gitPath, "rev-parse", "HEAD")
+	if err != nil {
+		return "", "", err
+	}
+	return strings.TrimSpace(branch), strings.TrimSpace(commit), nil
+}
+
+// describeDetachedHead - тег, на котором стоит HEAD, или короткий хэш коммита.
+func (s *GitService) describeDetachedHead(gitPath string) string {
+	if out, err := s.runGit(gitPath, "describe", "--tags", "--exact-match", "HEAD"); err == nil {
+		return strings.TrimSpace(out)
+	}
+	if out, err := s.runGit(gitPath, "rev-parse", "--short", "HEAD"); err == nil {
+		return strings.TrimSpace(out)
+	}
+	return ""
+}
+
+// gitCommitFormat - поля коммита через \x1f (см. parseCommitLine)
+const gitCommitFormat = "%H%x1f%h%x1f%an%x1f%ae%x1f%aI%x1f%s"
+
+func (s *GitService) headCommit(gitPath string) (*domain.GitCommit, error) {
+	// в пустом репозитории коммитов ещё нет
+	if _, err := s.runGit(gitPath, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return nil, nil
+	}
+
+	out, err := s.runGit(gitPath, "log", "-1", "--format="+gitCommitFormat, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	return parseCommitLine(strings.TrimSpace(out))
+}
+
+func parseCommitLine(line string) (*domain.GitCommit, error) {
+	parts := strings.Split(line, "\x1f")
+	if len(parts) < 6 {
+		return nil, fmt.Errorf("unexpected git log output: %q", line)
+	}
+
+	date, err := time.Parse(time.RFC3339, parts[4])
+	if err != nil {
+		return nil, fmt.Errorf("parse commit date %q: %w", parts[4], err)
+	}
+
+	return &domain.GitCommit{
+		Hash:        parts[0],
+		ShortHash:   parts[1],
+		Author:      parts[2],
+		AuthorEmail: parts[3],
+		Date:        date,
+		Message:     parts[5],
+	}, nil
+}
+
+// parsePorcelainV2Status разбирает вывод "git status --porcelain=v2 --branch".
+func parsePorcelainV2Status(out string) *domain.RepoStatus {
+	status := &domain.RepoStatus{
+		Staged:    []string{},
+		Unstaged:  []string{},
+		Untracked: []string{},
+	}
+
+	for _, line := range splitNonEmptyLines(out) {
+		switch {
+		case strings.HasPrefix(line, "# branch.head "):
+			head := strings.TrimPrefix(line, "# branch.head ")
+			if head == "(detached)" {
+				status.Detached = true
+			} else {
+				status.Branch = head
+			}
+
+		case strings.HasPrefix(line, "# branch.upstream "):
+			status.Upstream = strings.TrimPrefix(line, "# branch.upstream ")
+
+		case strings.HasPrefix(line, "# branch.ab "):
+			_, _ = fmt.Sscanf(strings.TrimPrefix(line, "# branch.ab "), "+%d -%d", &status.Ahead, &status.Behind)
+
+		case strings.HasPrefix(line, "1 "), strings.HasPrefix(line, "2 "), strings.HasPrefix(line, "u "):
+			// 1 XY sub mH mI mW hH hI path
+			// 2 XY sub mH mI mW hH hI Xscore path<TAB>origPath
+			// u XY sub m1 m2 m3 mW h1 h2 h3 path
+			fieldsBeforePath := map[byte]int{'1': 8, '2': 9, 'u': 10}[line[0]]
+			fields := strings.SplitN(line, " ", fieldsBeforePath+1)
+			if len(fields) <= fieldsBeforePath {
+				continue
+			}
+			path, _, _ := strings.Cut(fields[fieldsBeforePath], "\t")
+			xy := fields[1]
+
+			if line[0] == 'u' {
+				status.Unstaged = append(status.Unstaged, path)
+				continue
+			}
+			if xy[0] != '.' {
+				status.Staged = append(status.Staged, path)
+			}
+			if len(xy) > 1 && xy[1] != '.' {
+				status.Unstaged = append(status.Unstaged, path)
+			}
+
+		case strings.HasPrefix(line, "? "):
+			status.Untracked = append(status.Untracked, strings.TrimPrefix(line, "? "))
+		}
+	}
+
+	return status
+}
+
+// jacStashMarker - префикс сообщения stash, созданного JAC
+const jacStashMarker = "jac-autostash"
+
+// ListStashes возвращает stash'и, созданные JAC (новые — первыми).
+func (s *GitService) ListStashes(gitPath string) ([]domain.StashEntry, error) {
+	out, err := s.runGit(gitPath, "stash", "list", "--format=%gd%x1f%H%x1f%gs%x1f%cI")
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.StashEntry, 0)
+	for _, line := range splitNonEmptyLines(out) {
+		parts := strings.Split(line, "\x1f")
+		if len(parts) < 4 || !strings.Contains(parts[2], jacStashMarker) {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, parts[3])
+		res = append(res, domain.StashEntry{
+			Ref:     parts[0],
+			Hash:    parts[1],
+			Message: parts[2],
+			Date:    date,
+		})
+	}
+	return res, nil
+}
+
+// ApplyStash применяет stash JAC и удаляет его. При конфликтах stash остаётся,
+// а список конфликтующих файлов возвращается вместе с ошибкой.
+func (s *GitService) ApplyStash(gitPath string, hash string) ([]string, error) {
+	if err := s.applyStash(gitPath, hash, true); err != nil {
+		conflicts, _ := s.conflictedFiles(gitPath)
+		return conflicts, fmt.Errorf("failed to apply stash %s: %w (stash is kept)", hash, err)
+	}
+	return nil, nil
+}
+
+// DropStash удаляет stash JAC; чужие stash'и не трогаются.
+func (s *GitService) DropStash(gitPath string, hash string) error {
+	ref, err := s.jacStashRef(gitPath, hash)
+	if err != nil {
+		return err
+	}
+	_, err = s.runGit(gitPath, "stash", "drop", ref)
+	return err
+}
+
+func (s *GitService) stashPush(gitPath string, targetBranch string) (string, error) {
+	message := fmt.Sprintf("%s: switch to %s at %s", jacStashMarker, targetBranch, time.Now().Format("2006-01-02 15:04:05"))
+	if _, err := s.runGit(gitPath, "stash", "push", "--include-untracked", "-m", message); err != nil {
+		return "", fmt.Errorf("failed to stash changes: %w", err)
+	}
+	return s.revParse(gitPath, "stash@{0}")
+}
+
+// applyStash восстанавливает изменения вместе с индексом (staged остаются staged).
+// Если индекс восстановить нельзя и git ничего не изменил, изменения применяются без индекса.
+func (s *GitService) applyStash(gitPath string, hash string, drop bool) error {
+	ref, err := s.jacStashRef(gitPath, hash)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.runGit(gitPath, "stash", "apply", "--index", ref); err != nil {
+		if conflicts, cerr := s.conflictedFiles(gitPath); cerr != nil || len(conflicts) > 0 {
+			return err
+		}
+		s.logger.Warn("failed to apply stash with index, retrying without it", "path", gitPath, "stash", hash, "err", err)
+		if _, err := s.runGit(gitPath, "stash", "apply", ref); err != nil {
+			return err
+		}
+	}
+	if !drop {
+		return nil
+	}
+	if _, err := s.runGit(gitPath, "stash", "drop", ref); err != nil {
+		s.logger.Warn("failed to drop applied stash", "path", gitPath, "stash", hash, "err", err)
+	}
+	return nil
+}
+
+// jacStashRef - ref (stash@{n}) stash'а JAC по хэшу или его префиксу не короче 7 символов.
+// Stash'и, созданные не JAC, и произвольные ревизии не принимаются.
+func (s *GitService) jacStashRef(gitPath string, hash string) (string, error) {
+	if err := validateRev(hash); err != nil {
+		return "", err
+	}
+	stashes, err := s.ListStashes(gitPath)
+	if err != nil {
+		return "", err
+	}
+	for _, st := range stashes {
+		if st.Hash == hash || (len(hash) >= 7 && strings.HasPrefix(st.Hash, hash)) {
+			return st.Ref, nil
+		}
+	}
+	return "", fmt.Errorf("stash JAC not found: %s", hash)
+}
+
+func (s *GitService) conflictedFiles(gitPath string) ([]string, error) {
+	out, err := s.runGit(gitPath, "-c", "core.quotePath=false", "diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil, err
+	}
+	return splitNonEmptyLines(out), nil
+}
+
 func (s *GitService) isWorkingTreeClean(repoPath string) (bool, error) {
 	out, err := s.runGit(repoPath, "status", "--porcelain")
 	if err != nil {
@@ -124,22 +1118,13 @@ func (s *GitService) isWorkingTreeClean(repoPath string) (bool, error) {
 }
 
 func (s *GitService) hasLocalBranch(gitPath string, branch string) (bool, error) {
-	cmd := newGitCmd(gitPath, "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
-	err := cmd.Run()
-	if err == nil {
-		return true, nil
-	}
-	return false, nil
+	_, err := s.runGit(gitPath, "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
+	return err == nil, nil
 }
 
 func (s *GitService) hasRemoteBranch(gitPath string, remoteBranch string) (bool, error) {
-	ref := "refs/remotes/" + remoteBranch
-	cmd := newGitCmd(gitPath, "show-ref", "--verify", "--quiet", ref)
-	err := cmd.Run()
-	if err == nil {
-		return true, nil
-	}
-	return false, nil
+	_, err := s.runGit(gitPath, "show-ref", "--verify", "--quiet", "refs/remotes/"+remoteBranch)
+	return err == nil, nil
 }
 
 func splitNonEmptyLines(s string) []string {
@@ -155,22 +1140,87 @@ func splitNonEmptyLines(s string) []string {
 }
 
 func (s *GitService) fetch(gitPath string) error {
-	_, err := s.runGit(gitPath, "fetch", "--all", "--prune")
+	_, err := s.runGitWithProgress(gitPath, "fetch", "fetch", "--all", "--prune", "--progress")
 	return err
 }
 
+// Cancel прерывает git команды, выполняющиеся в репозитории gitPath.
+// Возвращает количество прерванных команд.
+func (s *GitService) Cancel(gitPath string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ops := s.running[filepath.Clean(gitPath)]
+	for _, cancel := range ops {
+		cancel()
+	}
+	return len(ops)
+}
+
+// CancelAll прерывает все выполняющиеся git команды.
+func (s *GitService) CancelAll() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, ops := range s.running {
+		for _, cancel := range ops {
+			cancel()
+			n++
+		}
+	}
+	return n
+}
+
 func (s *GitService) runGit(gitPath string, args ...string) (string, error) {
-	cmd := newGitCmd(gitPath, args...)
+	return s.runGitWithProgress(gitPath, "", args...)
+}
+
+// runGitWithProgress выполняет git с таймаутом GitTimeoutSec; команду можно прервать через Cancel.
+// Если задан operation, stderr построчно (включая строки прогресса, разделённые \r)
+// отправляется событием "git:progress", а в текст ошибки попадают только строки без процентов.
+func (s *GitService) runGitWithProgress(gitPath string, operation string, args ...string) (string, error) {
+	ctx, done := s.startCommand(gitPath)
+	defer done()
+
+	cmd := newGitCmd(ctx, gitPath, args...)
 
 	var out bytes.Buffer
 	var errBuf bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &errBuf
 
-	if err := cmd.Run(); err != nil {
+	if operation != "" {
+		cmd.Stderr = &gitProgressWriter{
+			onLine: func(line string) {
+				progress := parseGitProgress(line)
+				progress.GitPath = gitPath
+				progress.Operation = operation
+				runtime.EventsEmit(s.ctx, gitProgressEventKey, progress)
+
+				if progress.Percent < 0 {
+					errBuf.WriteString(line + "\n")
+				}
+			},
+		}
+	}
+
+	err := cmd.Run()
+	if w, ok := cmd.Stderr.(*gitProgressWriter); ok {
+		w.flush()
+	}
+
+	if err != nil {
+		switch ctx.Err() {
+		case context.DeadlineExceeded:
+			return out.String(), fmt.Errorf("git %s timed out after %s", args[0], s.timeout())
+		case context.Canceled:
+			return out.String(), fmt.Errorf("git %s canceled", args[0])
+		}
+
 		msg := strings.TrimSpace(errBuf.String())
 		if msg != "" {
-			return out.String(), fmt.Errorf(msg)
+			return out.String(), errors.New(msg)
 		}
 		return out.String(), err
 	}
@@ -178,9 +1228,60 @@ func (s *GitService) runGit(gitPath string, args ...string) (string, error) {
 	return out.String(), nil
 }
 
-func newGitCmd(gitPath string, args ...string) *exec.Cmd {
-	allArgs := append([]string{"-C", gitPath}, args...)
-	cmd := exec.Command("git", allArgs...)
+// startCommand создаёт контекст команды с таймаутом и регистрирует её для Cancel.
+// Возвращаемую функцию нужно вызвать после завершения команды.
+func (s *GitService) startCommand(gitPath string) (context.Context, func()) {
+	parent := s.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, s.timeout())
+
+	key := filepath.Clean(gitPath)
+
+	s.mu.Lock()
+	s.nextCmdID++
+	id := s.nextCmdID
+	if s.running[key] == nil {
+		s.running[key] = make(map[uint64]context.CancelFunc)
+	}
+	s.running[key][id] = cancel
+	s.mu.Unlock()
+
+	return ctx, func() {
+		cancel()
+
+		s.mu.Lock()
+		delete(s.running[key], id)
+		if len(s.running[key]) == 0 {
+			delete(s.running, key)
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *GitService) timeout() time.Duration {
+	if s.settingsService != nil && s.settingsService.Settings.GitTimeoutSec > 0 {
+		return time.Duration(s.settingsService.Settings.GitTimeoutSec) * time.Second
+	}
+	return defaultGitTimeout
+}
+
+// newGitCmd - git без интерактивных запросов: при отсутствии учётных данных
+// команда сразу завершается ошибкой, а не ждёт ввода пароля.
+func newGitCmd(ctx context.Context, gitPath string, args ...string) *exec.Cmd {
+	allArgs := append([]string{"-C", gitPath}, sshBatchModeArgs(gitPath)...)
+	allArgs = append(allArgs, args...)
+	cmd := exec.CommandContext(ctx, "git", allArgs...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GCM_INTERACTIVE=never")
+	// при отмене завершаем и дочерние процессы git (remote-https, ssh), иначе они держат stderr
+	cmd.Cancel = func() error {
+		if err := util.KillProcessTree(cmd.Process.Pid); err != nil {
+			return cmd.Process.Kill()
+		}
+		return nil
+	}
+	cmd.WaitDelay = gitWaitDelay
 
 	cmd.SysProcAttr = &syscall.SysProcAttr{
 		HideWindow: true,
@@ -188,3 +1289,61 @@ func newGitCmd(gitPath string, args ...string) *exec.Cmd {
 
 	return cmd
 }
+
+// sshBatchModeArgs включает BatchMode для ssh (без него ssh ждёт ввода пароля или подтверждения
+// ключа хоста в скрытой консоли), если пользователь не настроил ssh сам через GIT_SSH_COMMAND,
+// GIT_SSH или core.sshCommand.
+func sshBatchModeArgs(gitPath string) []string {
+	if os.Getenv("GIT_SSH_COMMAND") != "" || os.Getenv("GIT_SSH") != "" {
+		return nil
+	}
+
+	cmd := exec.Command("git", "-C", gitPath, "config", "--get", "core.sshCommand")
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		HideWindow: true,
+	}
+	// git config завершается с кодом 1, если параметр не задан
+	if out, err := cmd.Output(); err == nil && strings.TrimSpace(string(out)) != "" {
+		return nil
+	}
+	return []string{"-c", "core.sshCommand=ssh -o BatchMode=yes"}
+}
+
+// gitProgressWriter делит вывод git на строки по \n и \r (прогресс перерисовывается через \r).
+type gitProgressWriter struct {
+	buf    []byte
+	onLine func(line string)
+}
+
+func (w *gitProgressWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexAny(w.buf, "\r\n")
+		if i < 0 {
+			break
+		}
+		if line := strings.TrimSpace(string(w.buf[:i])); line != "" {
+			w.onLine(line)
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *gitProgressWriter) flush() {
+	if line := strings.TrimSpace(string(w.buf)); line != "" {
+		w.onLine(line)
+	}
+	w.buf = nil
+}
+
+var gitProgressPercentRe = regexp.MustCompile(`(\d{1,3})%`)
+
+// parseGitProgress - "Receiving objects:  45% (9/20)" -> Percent 45; строки без процентов — Percent -1.
+func parseGitProgress(line string) domain.GitProgress {
+	progress := domain.GitProgress{Message: line, Percent: -1}
+	if m := gitProgressPercentRe.FindStringSubmatch(line); m != nil {
+		progress.Percent, _ = strconv.Atoi(m[1])
+	}
+	return progress
+}
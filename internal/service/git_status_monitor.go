@@ -0,0 +1,136 @@
+package service
+
+import (
+	"central-desktop/internal/domain"
+	"context"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	defaultGitStatusRefreshInterval = 60 * time.Second
+	gitStatusEventKey               = "git:status"
+)
+
+// GitRepoRef - приложение и путь к его репозиторию для фонового обновления статуса.
+type GitRepoRef struct {
+	AppName string
+	GitPath string
+}
+
+// GitStatusMonitor периодически обновляет статус репозиториев приложений и кэширует его.
+// Обновление не делает fetch, поэтому ahead/behind считаются от последнего fetch.
+// События:
+// - "git:status" -> payload: map[string]*domain.RepoStatus (appName -> статус)
+type GitStatusMonitor struct {
+	logger     *slog.Logger
+	ctx        context.Context
+	gitService *GitService
+	mu         sync.RWMutex
+	statuses   map[string]*domain.RepoStatus
+	cancel     context.CancelFunc
+}
+
+func NewGitStatusMonitor(lg *slog.Logger, gs *GitService, ctx context.Context) *GitStatusMonitor {
+	lg.Info("Initializing git status monitor")
+	return &GitStatusMonitor{
+		logger:     lg,
+		ctx:        ctx,
+		gitService: gs,
+		statuses:   make(map[string]*domain.RepoStatus),
+	}
+}
+
+// Start запускает фоновое обновление; repos вызывается на каждом цикле,
+// чтобы подхватывать изменения списка приложений.
+func (m *GitStatusMonitor) Start(interval time.Duration, repos func() []GitRepoRef) {
+	m.Stop()
+
+	if interval <= 0 {
+		interval = defaultGitStatusRefreshInterval
+	}
+
+	mctx, cancel := context.WithCancel(m.ctx)
+	m.mu.Lock()
+	m.cancel = cancel
+	m.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			m.refreshAll(repos())
+
+			select {
+			case <-mctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (m *GitStatusMonitor) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+}
+
+// Refresh обновляет статус одного приложения сразу, не дожидаясь фонового цикла.
+func (m *GitStatusMonitor) Refresh(appName string, gitPath string) (*domain.RepoStatus, error) {
+	status, err := m.gitService.GetStatus(gitPath)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.statuses[appName] = status
+	m.mu.Unlock()
+
+	runtime.EventsEmit(m.ctx, gitStatusEventKey, map[string]*domain.RepoStatus{appName: status})
+	return status, nil
+}
+
+// Get возвращает последний известный статус (nil, если ещё не получен).
+func (m *GitStatusMonitor) Get(appName string) *domain.RepoStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.statuses[appName]
+}
+
+func (m *GitStatusMonitor) refreshAll(repos []GitRepoRef) {
+	updated := make(map[string]*domain.RepoStatus, len(repos))
+	// приложения из одного репозитория получают один и тот же статус
+	byPath := make(map[string]*domain.RepoStatus, len(repos))
+	for _, repo := range repos {
+		key := filepath.Clean(repo.GitPath)
+		status, ok := byPath[key]
+		if !ok {
+			var err error
+			status, err = m.gitService.GetStatus(repo.GitPath)
+			if err != nil {
+				m.logger.Warn("failed to refresh git status", "app", repo.AppName, "err", err)
+				continue
+			}
+			byPath[key] = status
+		}
+		updated[repo.AppName] = status
+	}
+
+	m.mu.Lock()
+	m.statuses = updated
+	m.mu.Unlock()
+
+	if len(updated) > 0 {
+		runtime.EventsEmit(m.ctx, gitStatusEventKey, updated)
+	}
+}
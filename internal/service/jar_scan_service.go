@@ -0,0 +1,93 @@
+package service
+
+import (
+	"central-desktop/internal/domain"
+	"central-desktop/internal/util"
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	jarScanProgressEventKey = "jars:scan-progress"
+	jarScanProgressInterval = 100 * time.Millisecond
+)
+
+// JarScanService ищет jar в папке приложения для выбора JarPath. Одновременно выполняется
+// одно сканирование: новое прерывает предыдущее.
+// События:
+// - "jars:scan-progress" -> payload: domain.JarScanProgress (не чаще раза в 100 мс и в конце)
+type JarScanService struct {
+	logger          *slog.Logger
+	ctx             context.Context
+	settingsService *SettingsService
+	mu              sync.Mutex
+	cancel          context.CancelFunc
+	scanID          uint64
+}
+
+func NewJarScanService(lg *slog.Logger, ss *SettingsService, ctx context.Context) *JarScanService {
+	lg.Info("Initializing jar scan service")
+	return &JarScanService{logger: lg, settingsService: ss, ctx: ctx}
+}
+
+// Scan возвращает найденные в baseDir jar в порядке ранжирования с учётом JarScanIgnore из настроек.
+func (s *JarScanService) Scan(baseDir string) ([]domain.JarCandidate, error) {
+	sctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.cancel = cancel
+	s.scanID++
+	id := s.scanID
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		// следующее сканирование могло уже заменить cancel
+		if s.scanID == id {
+			s.cancel = nil
+		}
+		s.mu.Unlock()
+	}()
+
+	var lastEmit time.Time
+	progress := func(p domain.JarScanProgress) {
+		if !p.Done && time.Since(lastEmit) < jarScanProgressInterval {
+			return
+		}
+		lastEmit = time.Now()
+		runtime.EventsEmit(s.ctx, jarScanProgressEventKey, p)
+	}
+
+	started := time.Now()
+	candidates, err := util.ScanJarCandidates(sctx, baseDir, s.settingsService.Settings.JarScanIgnore, progress)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			s.logger.Info("jar scan canceled", "baseDir", baseDir)
+		}
+		return nil, err
+	}
+
+	s.logger.Info("jar scan finished", "baseDir", baseDir, "jars", len(candidates), "duration", time.Since(started))
+	return candidates, nil
+}
+
+// Cancel прерывает текущее сканирование. false - сканирование не выполнялось.
+func (s *JarScanService) Cancel() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel == nil {
+		return false
+	}
+	s.cancel()
+	return true
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"central-desktop/internal/domain"
+	"central-desktop/internal/util"
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+const (
+	jarStatusCacheTTL = 30 * time.Second
+	jarStatusEventKey = "jars:status"
+)
+
+// JarStatusChecker определяет, что jar приложения устарел: собран из коммита, после которого
+// в папке приложения появились новые коммиты, или исходники изменены после сборки.
+// Статусы считаются в фоне (см. Start) и кэшируются, чтобы не обходить исходники на каждый запрос.
+// События:
+// - "jars:status" -> payload: map[string]*domain.JarStatus (appName -> статус)
+type JarStatusChecker struct {
+	logger     *slog.Logger
+	ctx        context.Context
+	gitService *GitService
+	mu         sync.Mutex
+	cache      map[string]jarStatusEntry
+	cancel     context.CancelFunc
+	wake       chan struct{}
+}
+
+type jarStatusEntry struct {
+	jarPath string
+	status  *domain.JarStatus
+}
+
+func NewJarStatusChecker(lg *slog.Logger, gs *GitService, ctx context.Context) *JarStatusChecker {
+	lg.Info("Initializing jar status checker")
+	return &JarStatusChecker{
+		logger:     lg,
+		ctx:        ctx,
+		gitService: gs,
+		cache:      make(map[string]jarStatusEntry),
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// Start запускает фоновую проверку; apps вызывается на каждом цикле,
+// чтобы подхватывать изменения списка приложений.
+func (c *JarStatusChecker) Start(interval time.Duration, apps func() []domain.ApplicationInfo) {
+	c.Stop()
+
+	if interval <= 0 {
+		interval = defaultGitStatusRefreshInterval
+	}
+
+	mctx, cancel := context.WithCancel(c.ctx)
+	c.mu.Lock()
+	c.cancel = cancel
+	c.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			c.refreshAll(mctx, apps())
+
+			select {
+			case <-mctx.Done():
+				return
+			case <-ticker.C:
+			case <-c.wake:
+			}
+		}
+	}()
+}
+
+func (c *JarStatusChecker) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cancel != nil {
+		c.cancel()
+		c.cancel = nil
+	}
+}
+
+// Check возвращает статус из кэша или проверяет jar сразу (для явных действий пользователя).
+func (c *JarStatusChecker) Check(appInfo *domain.ApplicationInfo) *domain.JarStatus {
+	if status, fresh := c.cached(appInfo); fresh {
+		return status
+	}
+
+	status := c.check(appInfo)
+	c.store(appInfo, status)
+	return status
+}
+
+// Cached возвращает последний посчитанный статус без проверки (nil, если его ещё нет);
+// отсутствующий статус будет посчитан фоновой проверкой.
+func (c *JarStatusChecker) Cached(appInfo *domain.ApplicationInfo) *domain.JarStatus {
+	status, _ := c.cached(appInfo)
+	if status == nil {
+		c.requestRefresh()
+	}
+	return status
+}
+
+// Invalidate сбрасывает кэш приложения (после сборки или checkout) и запускает фоновую проверку.
+func (c *JarStatusChecker) Invalidate(appName string) {
+	c.mu.Lock()
+	delete(c.cache, appName)
+	c.mu.Unlock()
+	c.requestRefresh()
+}
+
+func (c *JarStatusChecker) cached(appInfo *domain.ApplicationInfo) (status *domain.JarStatus, fresh bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.cache[appInfo.AppName]
+	if !ok || entry.jarPath != appInfo.JarPath {
+		return nil, false
+	}
+	return entry.status, time.Since(entry.status.CheckedAt) < jarStatusCacheTTL
+}
+
+func (c *JarStatusChecker) store(appInfo *domain.ApplicationInfo, status *domain.JarStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache[appInfo.AppName] = jarStatusEntry{jarPath: appInfo.JarPath, status: status}
+}
+
+func (c *JarStatusChecker) requestRefresh() {
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (c *JarStatusChecker) refreshAll(ctx context.Context, apps []domain.ApplicationInfo) {
+	updated := make(map[string]*domain.JarStatus)
+	for i := range apps {
+		if ctx.Err() != nil {
+			return
+		}
+		ai := &apps[i]
+		if _, fresh := c.cached(ai); fresh {
+			continue
+		}
+		status := c.check(ai)
+		c.store(ai, status)
+		updated[ai.AppName] = status
+	}
+
+	if len(updated) > 0 {
+		runtime.EventsEmit(c.ctx, jarStatusEventKey, updated)
+	}
+}
+
+func (c *JarStatusChecker) check(appInfo *domain.ApplicationInfo) *domain.JarStatus {
+	status := &domain.JarStatus{CheckedAt: time.Now()}
+	if strings.TrimSpace(appInfo.JarPath) == "" {
+		return status
+	}
+
+	builtAt, err := util.JarBuildTime(appInfo.JarPath)
+	if err != nil {
+		status.Outdated = true
+		status.Reason = fmt.Sprintf("jar не найден: %s", appInfo.JarPath)
+		return status
+	}
+	status.BuiltAt = builtAt
+
+	if appInfo.HasGit {
+		status.JarCommit = util.JarGitCommit(appInfo.JarPath)
+	}
+	if status.JarCommit != "" {
+		committed, dirty, known, err := c.gitService.PathChangesSince(repoPath(appInfo), status.JarCommit, appInfo.BaseDir)
+		switch {
+		case err != nil:
+			c.logger.Warn("failed to compare jar commit with HEAD", "app", appInfo.AppName, "err", err)
+		case known && committed:
+			status.Outdated = true
+			status.Reason = fmt.Sprintf("jar собран из коммита %s, после него в папке приложения есть новые коммиты",
+				shortHash(status.JarCommit))
+			return status
+		case known && !dirty:
+			// HEAD совпадает с коммитом сборки и незакоммиченных изменений нет
+			return status
+		}
+	}
+
+	if path, ok := util.FindSourceChangedAfter(appInfo.BaseDir, builtAt); ok {
+		rel, err := filepath.Rel(appInfo.BaseDir, path)
+		if err != nil {
+			rel = path
+		}
+		status.Outdated = true
+		status.Reason = fmt.Sprintf("после сборки jar изменён %s", rel)
+	}
+	return status
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
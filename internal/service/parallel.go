@@ -0,0 +1,27 @@
+package service
+
+import "sync"
+
+const defaultMaxParallel = 4
+
+// runParallel вызывает fn(i) для i в [0, n), одновременно не больше limit вызовов.
+func runParallel(limit int, n int, fn func(i int)) {
+	if limit <= 0 {
+		limit = defaultMaxParallel
+	}
+
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
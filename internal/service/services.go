@@ -1,7 +1,13 @@
 package service
 
 type Services struct {
-	CentralService  *CentralService
-	SettingsService *SettingsService
-	GitService      *GitService
+	CentralService     *CentralService
+	SettingsService    *SettingsService
+	GitService         *GitService
+	AlertService       *AlertService
+	DiagnosticsService *DiagnosticsService
+	GitStatusMonitor   *GitStatusMonitor
+	BuildService       *BuildService
+	JarStatusChecker   *JarStatusChecker
+	JarScanService     *JarScanService
 }
@@ -6,6 +6,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"path/filepath"
+	"regexp"
 	"sync/atomic"
 )
 
@@ -14,6 +16,7 @@ type SettingsService struct {
 	ctx                   context.Context
 	Settings              *domain.AppSettings
 	minimizeToTrayOnClose atomic.Bool
+	onSave                []func(prev domain.AppSettings)
 }
 
 func NewSettingsService(lg *slog.Logger, ctx context.Context) *SettingsService {
@@ -41,9 +44,24 @@ func (s *SettingsService) MinimizeToTrayOnClose() bool {
 	return s.minimizeToTrayOnClose.Load()
 }
 
+// OnSave регистрирует обработчик, вызываемый после успешного сохранения настроек
+// с их предыдущими значениями. Регистрировать обработчики нужно при инициализации сервисов.
+func (s *SettingsService) OnSave(fn func(prev domain.AppSettings)) {
+	s.onSave = append(s.onSave, fn)
+}
+
 func (s *SettingsService) Save(settings *domain.AppSettings) error {
 	s.logger.Info("Settings service: Save called")
 
+	if _, err := regexp.Compile(settings.TraceIDPattern); err != nil {
+		return fmt.Errorf("некорректное регулярное выражение trace ID: %w", err)
+	}
+	for _, pattern := range settings.JarScanIgnore {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("некорректный шаблон игнорирования jar %q: %w", pattern, err)
+		}
+	}
+
 	if s.Settings.CentralInfoPath != settings.CentralInfoPath {
 
 		oldFilePath, errs := util.CentralInfoFilePath(s.Settings.CentralInfoPath)
@@ -67,10 +85,22 @@ func (s *SettingsService) Save(settings *domain.AppSettings) error {
 		return fmt.Errorf("не удалось записать настройки по пути: %s", settingsPath)
 	}
 
+	prev := *s.Settings
 	s.Settings.CentralInfoPath = settings.CentralInfoPath
 	s.Settings.MinimizeToTrayOnClose = settings.MinimizeToTrayOnClose
 	s.Settings.StartQuietMode = settings.StartQuietMode
+	s.Settings.TraceIDPattern = settings.TraceIDPattern
+	s.Settings.GitStatusRefreshSec = settings.GitStatusRefreshSec
+	s.Settings.GitMaxParallel = settings.GitMaxParallel
+	s.Settings.GitTimeoutSec = settings.GitTimeoutSec
+	s.Settings.BuildMaxParallel = settings.BuildMaxParallel
+	s.Settings.ReadinessTimeoutSec = settings.ReadinessTimeoutSec
+	s.Settings.DefaultGitBranch = settings.DefaultGitBranch
+	s.Settings.JarScanIgnore = settings.JarScanIgnore
 	s.minimizeToTrayOnClose.Store(settings.MinimizeToTrayOnClose)
 
+	for _, fn := range s.onSave {
+		fn(prev)
+	}
 	return nil
 }
@@ -45,7 +45,3 @@ func CentralInfoFilePath(dir string) (string, error) {
 func BuildCentralInfoFilePath(folderPath string) string {
 	return filepath.Join(folderPath, "central-info.json")
 }
-
-func BuildGitDirPath(dir string) string {
-	return filepath.Join(dir, ".git")
-}
@@ -0,0 +1,74 @@
+package util
+
+import (
+	"central-desktop/internal/domain"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ValidateBuildOptions проверяет параметры сборки приложения: инструмент есть в папке приложения,
+// у Gradle не заданы параметры, которые есть только у Maven, файлы и JDK существуют.
+func ValidateBuildOptions(appInfo *domain.ApplicationInfo) error {
+	opts := &appInfo.BuildOptions
+
+	hasMaven, err := HasMaven(appInfo.BaseDir)
+	if err != nil {
+		return fmt.Errorf("не удалось проверить pom.xml в %s: %w", appInfo.BaseDir, err)
+	}
+	hasGradle, err := HasGradle(appInfo.BaseDir)
+	if err != nil {
+		return fmt.Errorf("не удалось проверить файлы сборки Gradle в %s: %w", appInfo.BaseDir, err)
+	}
+	switch opts.Tool {
+	case domain.BuildToolAuto:
+	case domain.BuildToolMaven:
+		if !hasMaven {
+			return fmt.Errorf("для сборки Maven в папке приложения нет pom.xml")
+		}
+	case domain.BuildToolGradle:
+		if !hasGradle {
+			return fmt.Errorf("для сборки Gradle в папке приложения нет build.gradle или settings.gradle")
+		}
+	default:
+		return fmt.Errorf("неизвестный инструмент сборки %q", opts.Tool)
+	}
+
+	tool := opts.Tool
+	if tool == domain.BuildToolAuto && !hasMaven && hasGradle {
+		tool = domain.BuildToolGradle
+	}
+	if tool == domain.BuildToolGradle &&
+		(len(opts.Profiles) > 0 || opts.SettingsFile != "" || opts.LocalRepository != "") {
+		return fmt.Errorf("профили, settings.xml и локальный репозиторий задаются только для Maven")
+	}
+
+	for _, p := range opts.Profiles {
+		if strings.TrimSpace(p) == "" || strings.ContainsAny(p, " ,") {
+			return fmt.Errorf("некорректное имя профиля Maven %q", p)
+		}
+	}
+	for _, p := range opts.Properties {
+		if strings.TrimSpace(p.Name) == "" || strings.ContainsAny(p.Name, " =") {
+			return fmt.Errorf("некорректное имя свойства сборки %q", p.Name)
+		}
+	}
+	// Maven запускается в BaseDir, поэтому относительный путь указывал бы не туда, где его проверяем
+	if opts.SettingsFile != "" && (!filepath.IsAbs(opts.SettingsFile) || !IsRegularFile(opts.SettingsFile)) {
+		return fmt.Errorf("файл настроек Maven не найден (нужен абсолютный путь): %s", opts.SettingsFile)
+	}
+	if opts.LocalRepository != "" && !filepath.IsAbs(opts.LocalRepository) {
+		return fmt.Errorf("путь к локальному репозиторию Maven должен быть абсолютным: %s", opts.LocalRepository)
+	}
+	if opts.JavaHome != "" && !IsRegularFile(filepath.Join(opts.JavaHome, "bin", "java.exe")) {
+		return fmt.Errorf("в %s не найден bin\\java.exe", opts.JavaHome)
+	}
+	return nil
+}
+
+// IsRegularFile - path существует и это обычный файл.
+func IsRegularFile(path string) bool {
+	st, err := os.Stat(path)
+	return err == nil && st.Mode().IsRegular()
+}
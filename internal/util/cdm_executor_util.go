@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
@@ -35,8 +36,8 @@ func RunApplication(appInfo *domain.ApplicationInfo) (*CommandResult, error) {
 	}
 
 	jarPath := appInfo.JarPath
-	javaArgs := buildJavaArgs(appInfo.AppArguments, jarPath)
-	inner := buildCmdInnerLine("java", javaArgs)
+	javaArgs := buildJavaArgs(appInfo, jarPath)
+	inner := buildCmdInnerLine("java", javaArgs, consoleCodePage(appInfo.OutputEncoding))
 
 	cmd := exec.Command("cmd.exe", "/C", "start", "", "/min", "cmd.exe", "/K", inner)
 	cmd.Env = append(os.Environ(), toEnvList(appInfo.EnvVariables)...)
@@ -74,7 +75,7 @@ func RunApplicationSilent(appInfo *domain.ApplicationInfo) (*CommandResult, erro
 
 	logPath := filepath.Join(logsDir, GetLogFileName(appInfo.AppName))
 
-	if err := RemoveFile(logPath); err != nil {
+	if err := RotateLogFile(logPath, filepath.Join(logsDir, GetPrevLogFileName(appInfo.AppName))); err != nil {
 		return nil, err
 	}
 
@@ -90,7 +91,7 @@ func RunApplicationSilent(appInfo *domain.ApplicationInfo) (*CommandResult, erro
 		}
 	}()
 
-	javaArgs := buildJavaArgs(appInfo.AppArguments, jarPath)
+	javaArgs := buildJavaArgs(appInfo, jarPath)
 
 	cmd := exec.Command("java", javaArgs...)
 	cmd.Env = append(os.Environ(), toEnvList(appInfo.EnvVariables)...)
@@ -120,15 +121,82 @@ func RunApplicationSilent(appInfo *domain.ApplicationInfo) (*CommandResult, erro
 // buildJavaArgs строит аргументы для "java" корректно.
 // На вход можно дать как ["--add-opens java.base/java.lang=ALL-UNNAMED"] (одна строка),
 // так и ["--add-opens", "java.base/java.lang=ALL-UNNAMED"] — на выходе будет правильно.
-func buildJavaArgs(appArgs []string, jarPath string) []string {
-	normalized := normalizeJvmArgs(appArgs)
+// Параметры кодировки (см. encodingJvmArgs) и порт ServerPort добавляются перед -jar.
+func buildJavaArgs(appInfo *domain.ApplicationInfo, jarPath string) []string {
+	normalized := normalizeJvmArgs(appInfo.AppArguments)
+	encodingArgs := encodingJvmArgs(appInfo)
 
-	args := make([]string, 0, len(normalized)+2)
+	args := make([]string, 0, len(normalized)+len(encodingArgs)+3)
 	args = append(args, normalized...)
+	args = append(args, encodingArgs...)
+	if appInfo.ServerPort > 0 && !hasJvmProperty(appInfo.AppArguments, "server.port") {
+		args = append(args, fmt.Sprintf("-Dserver.port=%d", appInfo.ServerPort))
+	}
 	args = append(args, "-jar", jarPath)
 	return args
 }
 
+// JavaCommandLine - команда запуска java для приложения (как в RunApplicationSilent).
+func JavaCommandLine(appInfo *domain.ApplicationInfo) []string {
+	return append([]string{"java"}, buildJavaArgs(appInfo, appInfo.JarPath)...)
+}
+
+// ServerPort - порт приложения: ServerPort или, если он не задан, -Dserver.port из AppArguments.
+// 0 - порт не задан.
+func ServerPort(appInfo *domain.ApplicationInfo) int {
+	if appInfo.ServerPort > 0 {
+		return appInfo.ServerPort
+	}
+	port := 0
+	for _, a := range normalizeJvmArgs(appInfo.AppArguments) {
+		if v, ok := strings.CutPrefix(a, "-Dserver.port="); ok {
+			if p, err := strconv.Atoi(v); err == nil {
+				port = p
+			}
+		}
+	}
+	return port
+}
+
+// WithoutJvmProperty возвращает appArgs без -D<prop>=...; остальные параметры из той же строки сохраняются.
+func WithoutJvmProperty(appArgs []string, prop string) []string {
+	prefix := "-D" + prop + "="
+	out := make([]string, 0, len(appArgs))
+	for _, a := range appArgs {
+		if !strings.Contains(a, prefix) {
+			out = append(out, a)
+			continue
+		}
+		kept := slices.DeleteFunc(normalizeJvmArgs([]string{a}), func(p string) bool {
+			return strings.HasPrefix(p, prefix)
+		})
+		if len(kept) > 0 {
+			out = append(out, strings.Join(kept, " "))
+		}
+	}
+	return out
+}
+
+// JavaVersion возвращает вывод "java -version" для java из PATH.
+func JavaVersion() (string, error) {
+	cmd := exec.Command("java", "-version")
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		HideWindow:    true,
+		CreationFlags: windows.CREATE_NO_WINDOW,
+	}
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("java -version failed: %w: %s", err, out.String())
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
+
 func normalizeJvmArgs(appArgs []string) []string {
 	out := make([]string, 0, len(appArgs))
 	for _, a := range appArgs {
@@ -148,9 +216,11 @@ func normalizeJvmArgs(appArgs []string) []string {
 	return out
 }
 
-func buildCmdInnerLine(exe string, args []string) string {
+func buildCmdInnerLine(exe string, args []string, codePage string) string {
 	var b strings.Builder
-	b.WriteString("chcp 1251 >nul & ")
+	if codePage != "" {
+		b.WriteString("chcp " + codePage + " >nul & ")
+	}
 
 	b.WriteString(escapeCmdArg(exe))
 	for _, a := range args {
@@ -279,3 +349,21 @@ func StopProcess(pid int) error {
 
 	return nil
 }
+
+// KillProcessTree завершает процесс вместе с дочерними процессами (taskkill /T).
+func KillProcessTree(pid int) error {
+	if pid <= 0 {
+		return fmt.Errorf("invalid pid: %d", pid)
+	}
+
+	cmd := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(pid))
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		HideWindow:    true,
+		CreationFlags: windows.CREATE_NO_WINDOW,
+	}
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to kill process tree %d: %w: %s", pid, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package util
+
+import (
+	"central-desktop/internal/domain"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+func ValidateOutputEncoding(enc domain.OutputEncoding) error {
+	switch enc {
+	case domain.OutputEncodingDefault, domain.OutputEncodingAuto, domain.OutputEncodingUTF8,
+		domain.OutputEncodingWindows1251, domain.OutputEncodingCP866:
+		return nil
+	default:
+		return fmt.Errorf("неподдерживаемая кодировка вывода: %s", enc)
+	}
+}
+
+// DecodeLogLine переводит строку лога в UTF-8.
+// Для auto (и значения по умолчанию) валидный UTF-8 оставляется как есть,
+// иначе выбирается windows-1251 или cp866 — по тому, где больше строчных кириллических букв.
+func DecodeLogLine(enc domain.OutputEncoding, line string) string {
+	switch enc {
+	case domain.OutputEncodingUTF8:
+		return line
+	case domain.OutputEncodingWindows1251:
+		return decodeCharmap(charmap.Windows1251, line)
+	case domain.OutputEncodingCP866:
+		return decodeCharmap(charmap.CodePage866, line)
+	}
+
+	if utf8.ValidString(line) {
+		return line
+	}
+
+	cp1251 := decodeCharmap(charmap.Windows1251, line)
+	cp866 := decodeCharmap(charmap.CodePage866, line)
+	if countLowerCyrillic(cp866) > countLowerCyrillic(cp1251) {
+		return cp866
+	}
+	return cp1251
+}
+
+func decodeCharmap(cm *charmap.Charmap, line string) string {
+	res, err := cm.NewDecoder().String(line)
+	if err != nil {
+		return line
+	}
+	return res
+}
+
+func countLowerCyrillic(s string) int {
+	n := 0
+	for _, r := range s {
+		if unicode.Is(unicode.Cyrillic, r) && unicode.IsLower(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// consoleCodePage - кодовая страница для chcp в консольном режиме запуска.
+// Пустое значение — не переключать кодовую страницу консоли.
+func consoleCodePage(enc domain.OutputEncoding) string {
+	switch enc {
+	case domain.OutputEncodingUTF8:
+		return "65001"
+	case domain.OutputEncodingCP866:
+		return "866"
+	case domain.OutputEncodingAuto:
+		return ""
+	default:
+		return "1251"
+	}
+}
+
+func javaCharsetName(enc domain.OutputEncoding) string {
+	switch enc {
+	case domain.OutputEncodingUTF8:
+		return "UTF-8"
+	case domain.OutputEncodingWindows1251:
+		return "windows-1251"
+	case domain.OutputEncodingCP866:
+		return "IBM866"
+	default:
+		return ""
+	}
+}
+
+// encodingJvmArgs - -D параметры кодировки, если они включены для приложения
+// и не заданы пользователем явно в AppArguments.
+func encodingJvmArgs(appInfo *domain.ApplicationInfo) []string {
+	if !appInfo.PassEncodingArgs {
+		return nil
+	}
+	charset := javaCharsetName(appInfo.OutputEncoding)
+	if charset == "" {
+		return nil
+	}
+
+	var res []string
+	for _, prop := range []string{"file.encoding", "stdout.encoding", "stderr.encoding"} {
+		if hasJvmProperty(appInfo.AppArguments, prop) {
+			continue
+		}
+		res = append(res, fmt.Sprintf("-D%s=%s", prop, charset))
+	}
+	return res
+}
+
+func hasJvmProperty(appArgs []string, prop string) bool {
+	prefix := "-D" + prop + "="
+	for _, a := range normalizeJvmArgs(appArgs) {
+		if strings.HasPrefix(a, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -7,13 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"io/fs"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
+	"golang.org/x/sys/windows"
 )
 
 func DefaultAppSettings() *domain.AppSettings {
@@ -28,16 +27,42 @@ func DefaultAppSettings() *domain.AppSettings {
 		CentralInfoPath:             ciPath,
 		MinimizeToTrayOnClose:       false,
 		StartQuietMode:              false,
+		TraceIDPattern:              DefaultTraceIDPattern,
+		GitStatusRefreshSec:         60,
+		GitMaxParallel:              4,
+		GitTimeoutSec:               120,
+		BuildMaxParallel:            2,
+		ReadinessTimeoutSec:         180,
 	}
 }
 
 func DefaultCentralInfo() *domain.CentralInfo {
 	return &domain.CentralInfo{
 		GlobalVariables:  []domain.EnvVariable{},
+		GlobalAlertRules: DefaultAlertRules(),
 		ApplicationInfos: []domain.ApplicationInfo{},
 	}
 }
 
+func DefaultAlertRules() []domain.AlertRule {
+	return []domain.AlertRule{
+		{
+			Name:        "Приложение не запустилось",
+			Pattern:     `APPLICATION FAILED TO START`,
+			Severity:    domain.AlertSeverityError,
+			CooldownSec: 60,
+			IsActive:    true,
+		},
+		{
+			Name:        "OutOfMemoryError",
+			Pattern:     `java\.lang\.OutOfMemoryError`,
+			Severity:    domain.AlertSeverityError,
+			CooldownSec: 300,
+			IsActive:    true,
+		},
+	}
+}
+
 func PickJarFile(ctx context.Context) (string, error) {
 	path, err := runtime.OpenFileDialog(ctx, runtime.OpenDialogOptions{
 		Title: "Выберите JAR файл",
@@ -67,16 +92,18 @@ func PickCentralInfoFolder(ctx context.Context) (string, error) {
 	return path, nil
 }
 
-func HasGitFolder(appDir string) (bool, error) {
-	info, err := os.Stat(BuildGitDirPath(appDir))
+func PickDiagnosticsArchivePath(ctx context.Context) (string, error) {
+	path, err := runtime.SaveFileDialog(ctx, runtime.SaveDialogOptions{
+		Title:           "Сохранить диагностический архив",
+		DefaultFilename: fmt.Sprintf("jac-diagnostics-%s.zip", time.Now().Format("20060102-150405")),
+		Filters: []runtime.FileFilter{
+			{DisplayName: "ZIP archive (*.zip)", Pattern: "*.zip"},
+		},
+	})
 	if err != nil {
-		if os.IsNotExist(err) {
-			return false, nil
-		}
-		return false, err
+		return "", err
 	}
-
-	return info.IsDir(), nil
+	return path, nil
 }
 
 func HasMaven(appDir string) (bool, error) {
@@ -91,7 +118,24 @@ func HasMaven(appDir string) (bool, error) {
 	return !info.IsDir(), nil
 }
 
-func PickBaseApplicationFolder(ctx context.Context) (*dto.PickBaseApplicationFolderDTO, error) {
+// HasGradle - в appDir есть сборка Gradle (build.gradle, settings.gradle, в том числе .kts).
+func HasGradle(appDir string) (bool, error) {
+	for _, name := range []string{"build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts"} {
+		info, err := os.Stat(filepath.Join(appDir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return false, err
+		}
+		if !info.IsDir() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func PickBaseApplicationFolder(ctx context.Context, ignore []string) (*dto.PickBaseApplicationFolderDTO, error) {
 	baseDir, err := runtime.OpenDirectoryDialog(ctx, runtime.OpenDialogOptions{
 		Title: "Выберите папку c .git",
 	})
@@ -102,10 +146,11 @@ func PickBaseApplicationFolder(ctx context.Context) (*dto.PickBaseApplicationFol
 		return nil, nil
 	}
 
-	jarPaths, err := ScanJars(baseDir)
+	candidates, err := ScanJarCandidates(ctx, baseDir, ignore, nil)
 	if err != nil {
 		return nil, err
 	}
+	jarPaths := JarCandidatePaths(candidates)
 	if len(jarPaths) < 1 {
 		return nil, errors.New("в выбранной директории не найдено ни одного .jar файла")
 	}
@@ -236,43 +281,56 @@ func RemoveFile(pathToFile string) error {
 	return fmt.Errorf("не удалось удалить файл логов: %s после %d попыток: %w", pathToFile, attempts, lastErr)
 }
 
-func ScanJars(baseDir string) ([]string, error) {
-	if baseDir == "" {
-		return nil, fmt.Errorf("baseDir is empty")
-	}
+func GetLogFileName(appName string) string {
+	return fmt.Sprintf("jac-%s.log", appName)
+}
 
-	var jars []string
+// GetPrevLogFileName - лог предыдущего запуска приложения (см. RotateLogFile).
+func GetPrevLogFileName(appName string) string {
+	return fmt.Sprintf("jac-%s.prev.log", appName)
+}
 
-	err := filepath.WalkDir(baseDir, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
+// GetBuildLogFileName - вывод сборки приложения, начатой в startedAt (у каждой сборки из истории свой лог).
+func GetBuildLogFileName(appName string, startedAt time.Time) string {
+	return fmt.Sprintf("jac-%s.build-%s.log", appName, startedAt.Format("20060102-150405.000"))
+}
 
-		if d.IsDir() {
-			return nil
-		}
+// GetTestLogFileName - вывод последнего запуска тестов приложения.
+func GetTestLogFileName(appName string) string {
+	return fmt.Sprintf("jac-%s.test.log", appName)
+}
 
-		if strings.EqualFold(filepath.Ext(d.Name()), ".jar") {
-			absPath, absErr := filepath.Abs(path)
-			if absErr != nil {
-				return absErr
-			}
-			jars = append(jars, absPath)
+// RotateLogFile сохраняет текущий лог как лог предыдущего запуска.
+// Если переименовать не получилось — текущий лог просто удаляется, как раньше.
+func RotateLogFile(logPath string, prevPath string) error {
+	if _, err := os.Stat(logPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil
 		}
-
-		return nil
-	})
-	if err != nil {
-		return nil, fmt.Errorf("scan jars in %s: %w", baseDir, err)
+		return err
 	}
 
-	return jars, nil
-}
-
-func GetLogFileName(appName string) string {
-	return fmt.Sprintf("jac-%s.log", appName)
+	if err := RemoveFile(prevPath); err != nil {
+		return RemoveFile(logPath)
+	}
+	if err := os.Rename(logPath, prevPath); err != nil {
+		return RemoveFile(logPath)
+	}
+	return nil
 }
 
+// openFile открывает лог на чтение, не мешая писать, переименовывать и удалять его
+// (os.Open не передаёт FILE_SHARE_DELETE, и RotateLogFile при следующем запуске падал бы).
 func openFile(filePath string) (*os.File, error) {
-	return os.Open(filePath)
+	path, err := windows.UTF16PtrFromString(filePath)
+	if err != nil {
+		return nil, err
+	}
+	h, err := windows.CreateFile(path, windows.GENERIC_READ,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, windows.OPEN_EXISTING, windows.FILE_ATTRIBUTE_NORMAL, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: filePath, Err: err}
+	}
+	return os.NewFile(uintptr(h), filePath), nil
 }
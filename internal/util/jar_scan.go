@@ -0,0 +1,258 @@
+package util
+
+import (
+	"archive/zip"
+	"central-desktop/internal/domain"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// jarScanSkipDirs - папки, в которых лежат зависимости, кэши и служебные файлы, а не jar приложения
+	jarScanSkipDirs = map[string]bool{
+		".git": true, ".idea": true, ".vscode": true, ".m2": true, ".gradle": true, ".mvn": true,
+		".cache": true, "node_modules": true, "src": true,
+	}
+	// jarScanSkipTargetDirs - подпапки target/ с зависимостями (maven-dependency-plugin)
+	jarScanSkipTargetDirs = map[string]bool{"dependency": true, "lib": true, "libs": true}
+	// auxiliaryJarSuffixes - вспомогательные артефакты сборки, которые нельзя запустить
+	auxiliaryJarSuffixes = []string{"-plain", "-sources", "-javadoc", "-tests", "-test-sources"}
+)
+
+// JarScanProgressFunc вызывается при входе в каждую папку во время сканирования.
+type JarScanProgressFunc func(progress domain.JarScanProgress)
+
+// ScanJars - пути к jar из baseDir в порядке ранжирования (см. ScanJarCandidates) без дополнительного
+// списка игнорирования.
+func ScanJars(baseDir string) ([]string, error) {
+	candidates, err := ScanJarCandidates(context.Background(), baseDir, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return JarCandidatePaths(candidates), nil
+}
+
+// ScanJarCandidates ищет jar в baseDir, пропуская служебные папки, зависимости и вспомогательные
+// артефакты (-sources, -javadoc, -plain, .jar.original). ignore - шаблоны filepath.Match, которые
+// сравниваются с именем и с путём относительно baseDir (через /). Результат отсортирован:
+// сначала исполняемые jar (есть Main-Class), затем лежащие в target/ или build/libs/, затем более новые.
+// Сканирование прерывается отменой ctx.
+func ScanJarCandidates(ctx context.Context, baseDir string, ignore []string,
+	progress JarScanProgressFunc) ([]domain.JarCandidate, error) {
+	if baseDir == "" {
+		return nil, fmt.Errorf("baseDir is empty")
+	}
+	for _, pattern := range ignore {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("некорректный шаблон игнорирования %q: %w", pattern, err)
+		}
+	}
+
+	root, err := filepath.Abs(baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []domain.JarCandidate
+	state := domain.JarScanProgress{BaseDir: root}
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil {
+			// недоступные папки пропускаем, а не прерываем всё сканирование
+			if d != nil && d.IsDir() && path != root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+
+		rel, _ := filepath.Rel(root, path)
+		if path != root && isJarScanIgnored(d.Name(), filepath.ToSlash(rel), ignore) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			if path != root && isJarScanNoiseDir(path, d.Name()) {
+				return filepath.SkipDir
+			}
+			state.Dirs++
+			state.CurrentDir = path
+			if progress != nil {
+				progress(state)
+			}
+			return nil
+		}
+
+		if !strings.EqualFold(filepath.Ext(d.Name()), ".jar") || IsAuxiliaryJar(d.Name()) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		mainClass := jarMainClass(path)
+		candidates = append(candidates, domain.JarCandidate{
+			Path:        path,
+			MainClass:   mainClass,
+			Executable:  mainClass != "",
+			BuildOutput: isBuildOutputDir(filepath.Dir(path)),
+			Size:        info.Size(),
+			ModifiedAt:  info.ModTime(),
+		})
+		state.Jars++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan jars in %s: %w", baseDir, err)
+	}
+
+	slices.SortStableFunc(candidates, compareJarCandidates)
+
+	if progress != nil {
+		state.CurrentDir = ""
+		state.Done = true
+		progress(state)
+	}
+	return candidates, nil
+}
+
+// ValidateJarPattern проверяет шаблон jar: относительный путь с корректным синтаксисом filepath.Match.
+func ValidateJarPattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return nil
+	}
+	if filepath.IsAbs(pattern) || filepath.VolumeName(pattern) != "" {
+		return fmt.Errorf("шаблон jar должен быть относительным путём от папки приложения: %s", pattern)
+	}
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return fmt.Errorf("некорректный шаблон jar %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// ResolveJarPattern - самый новый исполняемый jar в baseDir, подходящий под pattern.
+// Вспомогательные артефакты (-sources, -plain и т.п.) не рассматриваются.
+func ResolveJarPattern(baseDir string, pattern string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(baseDir, pattern))
+	if err != nil {
+		return "", fmt.Errorf("некорректный шаблон jar %q: %w", pattern, err)
+	}
+
+	var found string
+	var foundTime time.Time
+	for _, path := range matches {
+		name := filepath.Base(path)
+		if !strings.EqualFold(filepath.Ext(name), ".jar") || IsAuxiliaryJar(name) {
+			continue
+		}
+		st, err := os.Stat(path)
+		if err != nil || !st.Mode().IsRegular() || jarMainClass(path) == "" {
+			continue
+		}
+		if found == "" || st.ModTime().After(foundTime) {
+			found = path
+			foundTime = st.ModTime()
+		}
+	}
+	if found == "" {
+		return "", fmt.Errorf("по шаблону %s в %s не найден исполняемый jar", pattern, baseDir)
+	}
+	return found, nil
+}
+
+func JarCandidatePaths(candidates []domain.JarCandidate) []string {
+	paths := make([]string, len(candidates))
+	for i := range candidates {
+		paths[i] = candidates[i].Path
+	}
+	return paths
+}
+
+// IsAuxiliaryJar - вспомогательный артефакт сборки (-plain, -sources, -javadoc, -tests),
+// а не приложение.
+func IsAuxiliaryJar(name string) bool {
+	base := strings.ToLower(strings.TrimSuffix(name, filepath.Ext(name)))
+	for _, suffix := range auxiliaryJarSuffixes {
+		if strings.HasSuffix(base, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isJarScanNoiseDir(path string, name string) bool {
+	if jarScanSkipDirs[name] {
+		return true
+	}
+	return jarScanSkipTargetDirs[name] && filepath.Base(filepath.Dir(path)) == "target"
+}
+
+func isJarScanIgnored(name string, relPath string, ignore []string) bool {
+	for _, pattern := range ignore {
+		pattern = filepath.ToSlash(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, relPath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isBuildOutputDir - папка артефактов Maven (target) или Gradle (build/libs).
+func isBuildOutputDir(dir string) bool {
+	name := filepath.Base(dir)
+	return name == "target" || (name == "libs" && filepath.Base(filepath.Dir(dir)) == "build")
+}
+
+// jarMainClass - Main-Class из MANIFEST.MF (пусто, если jar не исполняемый или не читается).
+func jarMainClass(jarPath string) string {
+	zr, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return ""
+	}
+	defer func() {
+		_ = zr.Close()
+	}()
+
+	f, err := zr.Open(manifestEntry)
+	if err != nil {
+		return ""
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return parseManifest(f)["Main-Class"]
+}
+
+func compareJarCandidates(a, b domain.JarCandidate) int {
+	if a.Executable != b.Executable {
+		if a.Executable {
+			return -1
+		}
+		return 1
+	}
+	if a.BuildOutput != b.BuildOutput {
+		if a.BuildOutput {
+			return -1
+		}
+		return 1
+	}
+	return b.ModifiedAt.Compare(a.ModifiedAt)
+}
@@ -0,0 +1,277 @@
+package util
+
+import (
+	"archive/zip"
+	"bufio"
+	"central-desktop/internal/domain"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	// gitPropertiesEntries - git.properties от git-commit-id-plugin / gradle-git-properties
+	gitPropertiesEntries = []string{"BOOT-INF/classes/git.properties", "WEB-INF/classes/git.properties", "git.properties"}
+	// manifestEntry - главные атрибуты jar: Main-Class, Start-Class, Implementation-Version, Build-Jdk-Spec
+	manifestEntry = "META-INF/MANIFEST.MF"
+	// buildInfoEntries - build-info.properties от spring-boot build-info
+	buildInfoEntries = []string{"BOOT-INF/classes/META-INF/build-info.properties", "META-INF/build-info.properties"}
+
+	// sourceSkipDirs - папки, изменения в которых не означают изменения исходников
+	sourceSkipDirs = map[string]bool{
+		".git": true, ".idea": true, ".vscode": true, ".gradle": true, ".mvn": true,
+		"target": true, "build": true, "out": true, "bin": true, "logs": true, "node_modules": true,
+	}
+	// buildFileNames - файлы сборки, изменение которых тоже требует пересборки
+	buildFileNames = map[string]bool{
+		"pom.xml": true, "build.gradle": true, "build.gradle.kts": true,
+		"settings.gradle": true, "settings.gradle.kts": true, "gradle.properties": true,
+	}
+)
+
+// ReadJarProperties читает первый найденный в jar .properties файл из entryNames.
+// Если ни одного нет, возвращается nil без ошибки.
+func ReadJarProperties(jarPath string, entryNames ...string) (map[string]string, error) {
+	zr, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = zr.Close()
+	}()
+	return readZipProperties(&zr.Reader, entryNames...), nil
+}
+
+// ReadJarInfo читает метаданные jar: Main-Class/Start-Class и версии из MANIFEST.MF,
+// версию и время сборки из build-info.properties, коммит из git.properties.
+// Отсутствующие файлы метаданных не считаются ошибкой — соответствующие поля остаются пустыми.
+func ReadJarInfo(jarPath string) (*domain.JarInfo, error) {
+	st, err := os.Stat(jarPath)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть jar %s: %w", jarPath, err)
+	}
+	defer func() {
+		_ = zr.Close()
+	}()
+
+	info := &domain.JarInfo{
+		JarPath:    jarPath,
+		Size:       st.Size(),
+		ModifiedAt: st.ModTime(),
+	}
+
+	if f, err := zr.Open(manifestEntry); err == nil {
+		manifest := parseManifest(f)
+		_ = f.Close()
+
+		info.MainClass = manifest["Main-Class"]
+		info.StartClass = manifest["Start-Class"]
+		info.SpringBootVersion = manifest["Spring-Boot-Version"]
+		info.Version = manifest["Implementation-Version"]
+		info.Name = manifest["Implementation-Title"]
+		info.CreatedBy = manifest["Created-By"]
+		info.BuildJdk = firstNonEmpty(manifest["Build-Jdk-Spec"], manifest["Build-Jdk"])
+	}
+	info.Executable = info.MainClass != ""
+
+	if props := readZipProperties(&zr.Reader, buildInfoEntries...); props != nil {
+		info.Group = props["build.group"]
+		info.Artifact = props["build.artifact"]
+		info.Name = firstNonEmpty(props["build.name"], info.Name)
+		info.Version = firstNonEmpty(props["build.version"], info.Version)
+		if t, err := time.Parse(time.RFC3339, props["build.time"]); err == nil {
+			info.BuildTime = t
+		}
+	}
+
+	if props := readZipProperties(&zr.Reader, gitPropertiesEntries...); props != nil {
+		info.GitCommit = firstNonEmpty(props["git.commit.id.full"], props["git.commit.id"], props["git.commit.id.abbrev"])
+		info.GitBranch = props["git.branch"]
+		info.GitCommitTime = props["git.commit.time"]
+		info.GitDirty = props["git.dirty"] == "true"
+	}
+
+	info.JavaVersion = classJavaVersion(&zr.Reader, firstNonEmpty(info.StartClass, info.MainClass), info.StartClass != "")
+	return info, nil
+}
+
+// JarGitCommit - полный (или короткий) хэш коммита из git.properties внутри jar.
+func JarGitCommit(jarPath string) string {
+	props, err := ReadJarProperties(jarPath, gitPropertiesEntries...)
+	if err != nil || props == nil {
+		return ""
+	}
+	return firstNonEmpty(props["git.commit.id.full"], props["git.commit.id"], props["git.commit.id.abbrev"])
+}
+
+// JarBuildTime - время сборки из build-info.properties, иначе время изменения файла jar.
+func JarBuildTime(jarPath string) (time.Time, error) {
+	if props, err := ReadJarProperties(jarPath, buildInfoEntries...); err == nil && props != nil {
+		if t, err := time.Parse(time.RFC3339, props["build.time"]); err == nil {
+			return t, nil
+		}
+	}
+
+	st, err := os.Stat(jarPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return st.ModTime(), nil
+}
+
+// FindSourceChangedAfter ищет в baseDir исходник (файл внутри папки src или файл сборки),
+// изменённый после since. Результаты сборки и служебные папки (см. sourceSkipDirs) пропускаются.
+// Возвращает первый найденный.
+func FindSourceChangedAfter(baseDir string, since time.Time) (string, bool) {
+	var found string
+	_ = filepath.WalkDir(baseDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != baseDir && sourceSkipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !buildFileNames[d.Name()] && !isUnderSrcDir(baseDir, path) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if info.ModTime().After(since) {
+			found = path
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return found, found != ""
+}
+
+func isUnderSrcDir(baseDir string, path string) bool {
+	rel, err := filepath.Rel(baseDir, filepath.Dir(path))
+	if err != nil {
+		return false
+	}
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		if part == "src" {
+			return true
+		}
+	}
+	return false
+}
+
+func readZipProperties(zr *zip.Reader, entryNames ...string) map[string]string {
+	for _, name := range entryNames {
+		f, err := zr.Open(name)
+		if err != nil {
+			continue
+		}
+		props := parseProperties(f)
+		_ = f.Close()
+		return props
+	}
+	return nil
+}
+
+// classJavaVersion - версия Java, под которую скомпилирован класс (major version class-файла - 44).
+// У Spring Boot jar классы приложения лежат в BOOT-INF/classes.
+func classJavaVersion(zr *zip.Reader, className string, bootJar bool) int {
+	if className == "" {
+		return 0
+	}
+	name := strings.ReplaceAll(className, ".", "/") + ".class"
+	if bootJar {
+		name = "BOOT-INF/classes/" + name
+	}
+
+	f, err := zr.Open(name)
+	if err != nil {
+		return 0
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(f, header); err != nil {
+		return 0
+	}
+	if binary.BigEndian.Uint32(header) != 0xCAFEBABE {
+		return 0
+	}
+	major := int(binary.BigEndian.Uint16(header[6:]))
+	if major < 45 {
+		return 0
+	}
+	return major - 44
+}
+
+// parseManifest разбирает MANIFEST.MF: "Name: value", строки продолжения начинаются с пробела.
+func parseManifest(r io.Reader) map[string]string {
+	attrs := make(map[string]string)
+	sc := bufio.NewScanner(r)
+	var last string
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if line == "" {
+			// дальше идут секции отдельных entry, нужны только главные атрибуты
+			break
+		}
+		if strings.HasPrefix(line, " ") && last != "" {
+			attrs[last] += line[1:]
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		last = strings.TrimSpace(name)
+		attrs[last] = strings.TrimSpace(value)
+	}
+	return attrs
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseProperties - упрощённый разбор java .properties: key=value или key:value,
+// комментарии # и !, без продолжения строк через \.
+func parseProperties(r io.Reader) map[string]string {
+	props := make(map[string]string)
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		i := strings.IndexAny(line, "=:")
+		if i < 0 {
+			props[line] = ""
+			continue
+		}
+		key := strings.TrimSpace(line[:i])
+		value := strings.TrimSpace(line[i+1:])
+		props[key] = strings.ReplaceAll(value, `\:`, ":")
+	}
+	return props
+}
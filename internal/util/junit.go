@@ -0,0 +1,136 @@
+package util
+
+import (
+	"central-desktop/internal/domain"
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// junitDetailsLimit - сколько символов стектрейса сохранять для каждого упавшего теста
+const junitDetailsLimit = 4000
+
+type junitSuite struct {
+	XMLName xml.Name
+	Name    string       `xml:"name,attr"`
+	Cases   []junitCase  `xml:"testcase"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Failures  []junitProblem `xml:"failure"`
+	Errors    []junitProblem `xml:"error"`
+	Skipped   *struct{}      `xml:"skipped"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// ParseJUnitReports разбирает отчёты JUnit XML (TEST-*.xml от Surefire, Failsafe и Gradle) в dirs
+// и их подпапках, записанные не раньше since. Отсутствующие папки пропускаются.
+// Возвращает сводку, упавшие тесты и пути разобранных отчётов.
+func ParseJUnitReports(since time.Time, dirs ...string) (domain.TestSummary, []domain.TestFailure, []string, error) {
+	var summary domain.TestSummary
+	var failures []domain.TestFailure
+	var reports []string
+
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			name := d.Name()
+			if d.IsDir() || !strings.HasPrefix(name, "TEST-") || !strings.EqualFold(filepath.Ext(name), ".xml") {
+				return nil
+			}
+			info, err := d.Info()
+			// отчёты предыдущих запусков не учитываем (с запасом в секунду на точность времени файла)
+			if err != nil || info.ModTime().Before(since.Add(-time.Second)) {
+				return nil
+			}
+
+			suites, err := readJUnitReport(path)
+			if err != nil {
+				return fmt.Errorf("не удалось разобрать отчёт %s: %w", path, err)
+			}
+			for _, suite := range suites {
+				collectJUnitSuite(suite, &summary, &failures)
+			}
+			reports = append(reports, path)
+			return nil
+		})
+		if err != nil {
+			return summary, failures, reports, err
+		}
+	}
+
+	slices.Sort(reports)
+	return summary, failures, reports, nil
+}
+
+// readJUnitReport - корневой элемент может быть как <testsuite>, так и <testsuites>.
+func readJUnitReport(path string) ([]junitSuite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var root junitSuite
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	if root.XMLName.Local == "testsuites" {
+		return root.Suites, nil
+	}
+	return []junitSuite{root}, nil
+}
+
+func collectJUnitSuite(suite junitSuite, summary *domain.TestSummary, failures *[]domain.TestFailure) {
+	for _, nested := range suite.Suites {
+		collectJUnitSuite(nested, summary, failures)
+	}
+
+	for _, tc := range suite.Cases {
+		summary.Total++
+		switch {
+		case len(tc.Failures) > 0:
+			summary.Failed++
+			*failures = append(*failures, toTestFailure(suite, tc, "failure", tc.Failures[0]))
+		case len(tc.Errors) > 0:
+			summary.Errors++
+			*failures = append(*failures, toTestFailure(suite, tc, "error", tc.Errors[0]))
+		case tc.Skipped != nil:
+			summary.Skipped++
+		default:
+			summary.Passed++
+		}
+	}
+}
+
+func toTestFailure(suite junitSuite, tc junitCase, kind string, p junitProblem) domain.TestFailure {
+	details := strings.TrimSpace(p.Body)
+	if len(details) > junitDetailsLimit {
+		details = details[:junitDetailsLimit] + "\n..."
+	}
+	return domain.TestFailure{
+		Suite:   firstNonEmpty(tc.ClassName, suite.Name),
+		Name:    tc.Name,
+		Kind:    kind,
+		Type:    p.Type,
+		Message: p.Message,
+		Details: details,
+	}
+}
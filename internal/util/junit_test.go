@@ -0,0 +1,139 @@
+package util
+
+import (
+	"central-desktop/internal/domain"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseJUnitReports(t *testing.T) {
+	tests := []struct {
+		name     string
+		report   string
+		summary  domain.TestSummary
+		failures []domain.TestFailure
+	}{
+		{
+			name: "корень testsuite",
+			report: `<testsuite name="com.example.FooTest">
+  <testcase name="ok" classname="com.example.FooTest"/>
+  <testcase name="skip" classname="com.example.FooTest"><skipped/></testcase>
+</testsuite>`,
+			summary: domain.TestSummary{Total: 2, Passed: 1, Skipped: 1},
+		},
+		{
+			name: "корень testsuites с вложенными наборами",
+			report: `<testsuites>
+  <testsuite name="com.example.ATest">
+    <testcase name="ok" classname="com.example.ATest"/>
+  </testsuite>
+  <testsuite name="com.example.Outer">
+    <testsuite name="com.example.Inner">
+      <testcase name="broken"><error message="boom" type="java.lang.IllegalStateException">trace</error></testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`,
+			summary: domain.TestSummary{Total: 2, Passed: 1, Errors: 1},
+			failures: []domain.TestFailure{
+				{Suite: "com.example.Inner", Name: "broken", Kind: "error", Type: "java.lang.IllegalStateException", Message: "boom", Details: "trace"},
+			},
+		},
+		{
+			name: "failure важнее error и skipped",
+			report: `<testsuite name="S">
+  <testcase name="t" classname="C">
+    <skipped/>
+    <error message="e" type="E">err</error>
+    <failure message="f" type="F">
+      fail
+    </failure>
+  </testcase>
+</testsuite>`,
+			summary: domain.TestSummary{Total: 1, Failed: 1},
+			failures: []domain.TestFailure{
+				{Suite: "C", Name: "t", Kind: "failure", Type: "F", Message: "f", Details: "fail"},
+			},
+		},
+		{
+			name: "error важнее skipped",
+			report: `<testsuite name="S">
+  <testcase name="t" classname="C"><skipped/><error message="e" type="E"/></testcase>
+</testsuite>`,
+			summary: domain.TestSummary{Total: 1, Errors: 1},
+			failures: []domain.TestFailure{
+				{Suite: "C", Name: "t", Kind: "error", Type: "E", Message: "e"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "TEST-report.xml")
+			if err := os.WriteFile(path, []byte(tt.report), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			summary, failures, reports, err := ParseJUnitReports(time.Time{}, dir)
+			if err != nil {
+				t.Fatalf("ParseJUnitReports: %v", err)
+			}
+			if summary != tt.summary {
+				t.Errorf("summary = %+v, want %+v", summary, tt.summary)
+			}
+			if !reflect.DeepEqual(failures, tt.failures) {
+				t.Errorf("failures = %+v, want %+v", failures, tt.failures)
+			}
+			if !reflect.DeepEqual(reports, []string{path}) {
+				t.Errorf("reports = %v, want [%s]", reports, path)
+			}
+		})
+	}
+}
+
+func TestParseJUnitReportsSince(t *testing.T) {
+	since := time.Now().Add(-time.Hour)
+	report := `<testsuite name="S"><testcase name="t"/></testsuite>`
+
+	tests := []struct {
+		name    string
+		file    string
+		modTime time.Time
+		want    bool
+	}{
+		{name: "свежий отчёт", file: "TEST-fresh.xml", modTime: since.Add(time.Minute), want: true},
+		{name: "в пределах секунды до since", file: "TEST-edge.xml", modTime: since.Add(-500 * time.Millisecond), want: true},
+		{name: "отчёт прошлого запуска", file: "TEST-old.xml", modTime: since.Add(-time.Minute), want: false},
+		{name: "не отчёт JUnit", file: "report.xml", modTime: since.Add(time.Minute), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "nested", tt.file)
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(report), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chtimes(path, tt.modTime, tt.modTime); err != nil {
+				t.Fatal(err)
+			}
+
+			summary, _, reports, err := ParseJUnitReports(since, dir, filepath.Join(dir, "missing"))
+			if err != nil {
+				t.Fatalf("ParseJUnitReports: %v", err)
+			}
+			if got := len(reports) == 1; got != tt.want {
+				t.Errorf("report parsed = %v, want %v (reports %v)", got, tt.want, reports)
+			}
+			if tt.want && summary.Total != 1 {
+				t.Errorf("summary.Total = %d, want 1", summary.Total)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package util
+
+import (
+	"central-desktop/internal/domain"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// DefaultTraceIDPattern - traceId из MDC/JSON (traceId=..., "traceId":"...")
+// и из префикса Micrometer Tracing [app,traceId,spanId].
+const DefaultTraceIDPattern = `(?i)(?:trace[_-]?id["']?\s*[=:]\s*["']?|\[[\w.-]+,)([0-9a-f][0-9a-f-]{7,})`
+
+type LogSource struct {
+	AppName  string                `json:"appName"`
+	Path     string                `json:"path"`
+	Encoding domain.OutputEncoding `json:"encoding"`
+}
+
+// MergedLogEntry - запись общей ленты логов нескольких приложений.
+type MergedLogEntry struct {
+	LogEntry
+	AppName string `json:"appName"`
+	TraceID string `json:"traceId"`
+}
+
+const (
+	// mergedHistoryMaxWait - дольше этого история не ждёт источники, которые так и не дочитались
+	mergedHistoryMaxWait = 30 * time.Second
+	// mergedLiveHoldBack - на сколько задерживаются записи живого хвоста: последняя запись файла
+	// отдаётся парсером только после logIdleFlushDelay, и более новые записи других файлов
+	// не должны уйти раньше неё
+	mergedLiveHoldBack = logIdleFlushDelay + time.Second
+	// mergedMaxHold - дольше этого запись не держится, даже если её время в будущем (другой часовой пояс)
+	mergedMaxHold = 10 * time.Second
+)
+
+// MergedLogTailer следит сразу за несколькими лог-файлами и отдаёт записи
+// одной лентой, упорядоченной по времени записи.
+type MergedLogTailer struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	active bool
+}
+
+func NewMergedLogTailer() *MergedLogTailer {
+	return &MergedLogTailer{}
+}
+
+// Start читает файлы с начала и затем следит за ними. Записи копятся и раз в
+// flushInterval отдаются пачкой, отсортированной по времени. Первая пачка отдаётся, когда
+// все файлы прочитаны целиком вместе с последней записью каждого (или не открылись),
+// поэтому история упорядочена полностью. Записи живого хвоста задерживаются на
+// mergedLiveHoldBack по их времени, чтобы поздно отданная последняя запись файла
+// встала на своё место.
+// traceIDPattern - регулярка для извлечения trace/correlation ID (первая группа или всё совпадение).
+// Если traceID не пустой — в ленту попадают только записи с этим ID.
+// События:
+// - "log:merged:entries" -> payload: []MergedLogEntry
+// - "log:merged:error" -> payload: string
+// - "log:merged:started" -> payload: []LogSource
+// - "log:merged:stopped" -> payload: nil
+func (t *MergedLogTailer) Start(ctx context.Context, sources []LogSource, traceIDPattern string, traceID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.active {
+		return fmt.Errorf("merged log tail already running")
+	}
+	if len(sources) == 0 {
+		return fmt.Errorf("no log sources")
+	}
+
+	if strings.TrimSpace(traceIDPattern) == "" {
+		traceIDPattern = DefaultTraceIDPattern
+	}
+	traceIDRe, err := regexp.Compile(traceIDPattern)
+	if err != nil {
+		return fmt.Errorf("invalid trace id pattern %q: %w", traceIDPattern, err)
+	}
+	traceID = strings.TrimSpace(traceID)
+
+	flushInterval := 500 * time.Millisecond
+	maxEntriesPerEmit := 2000
+	entriesEventName := "log:merged:entries"
+
+	tctx, cancel := context.WithCancel(ctx)
+	t.cancel = cancel
+	t.active = true
+
+	runtime.EventsEmit(ctx, "log:merged:started", sources)
+
+	type bufferedEntry struct {
+		entry   MergedLogEntry
+		arrived time.Time
+	}
+
+	var bufMu sync.Mutex
+	var buffer []bufferedEntry
+	// historyDone[i] - источник i прочитан до конца, а его последняя запись из прочитанного отдана
+	// парсером (следующей порцией строк или по простою), либо файл не открылся
+	historyDone := make([]bool, len(sources))
+	markHistoryDone := func(i int) {
+		bufMu.Lock()
+		historyDone[i] = true
+		bufMu.Unlock()
+	}
+
+	collect := func(src LogSource, entries []LogEntry, last *time.Time) {
+		if len(entries) == 0 {
+			return
+		}
+		now := time.Now()
+		merged := make([]bufferedEntry, 0, len(entries))
+		for _, e := range entries {
+			// записи без времени (баннер и т.п.) держим рядом с предыдущей записью источника
+			if e.Timestamp.IsZero() {
+				e.Timestamp = *last
+			} else {
+				*last = e.Timestamp
+			}
+
+			id := ExtractTraceID(traceIDRe, e)
+			if traceID != "" && !strings.EqualFold(id, traceID) {
+				continue
+			}
+			merged = append(merged, bufferedEntry{
+				entry:   MergedLogEntry{LogEntry: e, AppName: src.AppName, TraceID: id},
+				arrived: now,
+			})
+		}
+
+		bufMu.Lock()
+		buffer = append(buffer, merged...)
+		bufMu.Unlock()
+	}
+
+	var wg sync.WaitGroup
+	for i, src := range sources {
+		wg.Add(1)
+		go func(i int, src LogSource) {
+			defer wg.Done()
+
+			parser := NewLogParser()
+			var last time.Time
+			reads := 0
+
+			followLogFile(tctx, src.Path, logFollowOptions{
+				encoding: src.Encoding,
+				onLines: func(lines []string) {
+					collect(src, parser.Feed(lines), &last)
+					// первая порция - всё содержимое файла, вторая завершает его последнюю запись
+					if reads++; reads == 2 {
+						markHistoryDone(i)
+					}
+				},
+				onIdle: func() {
+					collect(src, parser.Flush(), &last)
+					markHistoryDone(i)
+				},
+				onReset: parser.Reset,
+				onError: func(msg string) {
+					markHistoryDone(i)
+					runtime.EventsEmit(ctx, "log:merged:error", fmt.Sprintf("%s: %s", src.AppName, msg))
+				},
+			})
+		}(i, src)
+	}
+
+	go func() {
+		defer func() {
+			wg.Wait()
+			t.mu.Lock()
+			t.active = false
+			t.cancel = nil
+			t.mu.Unlock()
+			runtime.EventsEmit(ctx, "log:merged:stopped")
+		}()
+
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
+
+		started := time.Now()
+		historyEmitted := false
+
+		for {
+			select {
+			case <-tctx.Done():
+				return
+			case <-ticker.C:
+				now := time.Now()
+
+				bufMu.Lock()
+				if !historyEmitted {
+					if slices.Contains(historyDone, false) && now.Sub(started) < mergedHistoryMaxWait {
+						bufMu.Unlock()
+						continue
+					}
+					historyEmitted = true
+				}
+
+				sort.SliceStable(buffer, func(i, j int) bool {
+					return buffer[i].entry.Timestamp.Before(buffer[j].entry.Timestamp)
+				})
+
+				// отдаём отсортированный префикс записей старше окна задержки; запись, которая
+				// держится дольше mergedMaxHold, отдаётся вместе со всеми перед ней
+				watermark := now.Add(-mergedLiveHoldBack)
+				n := 0
+				for i, b := range buffer {
+					if !b.entry.Timestamp.After(watermark) || now.Sub(b.arrived) >= mergedMaxHold {
+						n = i + 1
+					}
+				}
+				batch := make([]MergedLogEntry, n)
+				for i := range batch {
+					batch[i] = buffer[i].entry
+				}
+				buffer = slices.Delete(buffer, 0, n)
+				bufMu.Unlock()
+
+				if len(batch) == 0 {
+					continue
+				}
+
+				for i := 0; i < len(batch); i += maxEntriesPerEmit {
+					j := i + maxEntriesPerEmit
+					if j > len(batch) {
+						j = len(batch)
+					}
+					runtime.EventsEmit(ctx, entriesEventName, batch[i:j])
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (t *MergedLogTailer) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.cancel != nil {
+		t.cancel()
+	}
+}
+
+// ExtractTraceID ищет trace/correlation ID в заголовке записи, а затем в её продолжении.
+func ExtractTraceID(re *regexp.Regexp, entry LogEntry) string {
+	if re == nil {
+		return ""
+	}
+
+	find := func(s string) string {
+		m := re.FindStringSubmatch(stripAnsi(s))
+		if m == nil {
+			return ""
+		}
+		if len(m) > 1 && m[1] != "" {
+			return m[1]
+		}
+		return m[0]
+	}
+
+	if id := find(entry.Raw); id != "" {
+		return id
+	}
+	for _, line := range entry.Trace {
+		if id := find(line); id != "" {
+			return id
+		}
+	}
+	return ""
+}
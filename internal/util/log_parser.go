@@ -0,0 +1,254 @@
+package util
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type LogLevel string
+
+const (
+	LogLevelTrace   LogLevel = "TRACE"
+	LogLevelDebug   LogLevel = "DEBUG"
+	LogLevelInfo    LogLevel = "INFO"
+	LogLevelWarn    LogLevel = "WARN"
+	LogLevelError   LogLevel = "ERROR"
+	LogLevelUnknown LogLevel = "UNKNOWN"
+)
+
+// LogEntry - одна запись лога. Строки стектрейса и прочие продолжения
+// многострочного сообщения складываются в Trace предыдущей записи.
+// Continuation - продолжение уже отданной записи (строки пришли после её отдачи по простою):
+// заголовок тот же, а в Trace только новые строки, их нужно дописать к предыдущей записи.
+type LogEntry struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Level        LogLevel  `json:"level"`
+	Thread       string    `json:"thread"`
+	Logger       string    `json:"logger"`
+	Message      string    `json:"message"`
+	Raw          string    `json:"raw"`
+	Trace        []string  `json:"trace"`
+	Continuation bool      `json:"continuation"`
+}
+
+var (
+	ansiEscapeRe = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+	// 2024-01-15T10:23:45.123+03:00  INFO 12345 --- [app] [main] c.e.d.DemoApplication : Started
+	// 2024-01-15 10:23:45.123  INFO 12345 --- [           main] c.e.d.DemoApplication : Started
+	// 2024-01-15 10:23:45.123  INFO [demo,65a1f0c2b3d4e5f6,a1b2c3d4] 12345 --- [main] ... (Micrometer Tracing)
+	springLineRe = regexp.MustCompile(
+		`^(\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}:\d{2}(?:[.,]\d{1,9})?(?:Z|[+-]\d{2}:?\d{2})?)\s+` +
+			`(TRACE|DEBUG|INFO|WARN|WARNING|ERROR|FATAL)\s+(?:\[[^\]]*\]\s+)?(?:\d+\s+)?---\s+` +
+			`(?:\[[^\]]*\]\s+)?\[\s*([^\]]*?)\s*\]\s+(\S+)\s*:\s?(.*)$`)
+
+	// 10:23:45.123 [main] INFO  c.e.Foo - message
+	// 2024-01-15 10:23:45.123 [main] INFO  c.e.Foo - message
+	logbackLineRe = regexp.MustCompile(
+		`^((?:\d{4}-\d{2}-\d{2}[ T])?\d{2}:\d{2}:\d{2}(?:[.,]\d{1,9})?)\s+` +
+			`\[([^\]]*)\]\s+(TRACE|DEBUG|INFO|WARN|WARNING|ERROR|FATAL)\s+(\S+)\s+-\s?(.*)$`)
+)
+
+var logTimestampLayouts = []string{
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"15:04:05.999999999",
+}
+
+// LogParser разбирает строки логов в формате Spring Boot / Logback (текстовый паттерн
+// по умолчанию и JSON от logstash-logback-encoder) и склеивает многострочные записи.
+// Не потокобезопасен: рассчитан на использование из одной горутины tail'а.
+type LogParser struct {
+	pending *LogEntry
+	// flushed - запись, отданная через Flush: строки без заголовка после неё
+	// (медленный async appender дописал стектрейс) продолжают её, а не становятся отдельными записями
+	flushed *LogEntry
+}
+
+func NewLogParser() *LogParser {
+	return &LogParser{}
+}
+
+// Feed принимает очередную порцию строк и возвращает записи, которые точно завершены.
+// Последняя запись остаётся в буфере, т.к. к ней ещё могут дописаться строки стектрейса.
+func (p *LogParser) Feed(lines []string) []LogEntry {
+	var done []LogEntry
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+
+		entry, ok := ParseLogLine(line)
+		if ok {
+			if p.pending != nil {
+				done = append(done, *p.pending)
+			}
+			p.pending = entry
+			p.flushed = nil
+			continue
+		}
+
+		if p.pending == nil && p.flushed != nil {
+			cont := *p.flushed
+			cont.Trace = nil
+			cont.Continuation = true
+			p.pending = &cont
+		}
+
+		if p.pending != nil {
+			p.pending.Trace = append(p.pending.Trace, line)
+			continue
+		}
+
+		// строка без заголовка и без предыдущей записи (например, баннер Spring)
+		done = append(done, LogEntry{
+			Level:   LogLevelUnknown,
+			Message: stripAnsi(line),
+			Raw:     line,
+		})
+	}
+
+	return done
+}
+
+// Flush отдаёт накопленную незавершённую запись, если она есть. Строки без заголовка,
+// пришедшие после этого, отдаются записью-продолжением (см. LogEntry.Continuation).
+func (p *LogParser) Flush() []LogEntry {
+	if p.pending == nil {
+		return nil
+	}
+	entry := *p.pending
+	p.flushed = p.pending
+	p.pending = nil
+	return []LogEntry{entry}
+}
+
+// Reset сбрасывает состояние парсера (например, при усечении файла).
+func (p *LogParser) Reset() {
+	p.pending = nil
+	p.flushed = nil
+}
+
+// ParseLogLine пробует распознать строку как заголовок записи лога.
+func ParseLogLine(line string) (*LogEntry, bool) {
+	clean := strings.TrimSpace(stripAnsi(line))
+	if clean == "" {
+		return nil, false
+	}
+
+	if strings.HasPrefix(clean, "{") {
+		return parseJSONLogLine(clean, line)
+	}
+
+	if m := springLineRe.FindStringSubmatch(clean); m != nil {
+		return &LogEntry{
+			Timestamp: parseLogTimestamp(m[1]),
+			Level:     normalizeLogLevel(m[2]),
+			Thread:    m[3],
+			Logger:    m[4],
+			Message:   m[5],
+			Raw:       line,
+		}, true
+	}
+
+	if m := logbackLineRe.FindStringSubmatch(clean); m != nil {
+		return &LogEntry{
+			Timestamp: parseLogTimestamp(m[1]),
+			Level:     normalizeLogLevel(m[3]),
+			Thread:    m[2],
+			Logger:    m[4],
+			Message:   m[5],
+			Raw:       line,
+		}, true
+	}
+
+	return nil, false
+}
+
+func parseJSONLogLine(clean string, raw string) (*LogEntry, bool) {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(clean), &fields); err != nil {
+		return nil, false
+	}
+
+	level := jsonLogField(fields, "level", "log.level", "severity")
+	message := jsonLogField(fields, "message", "msg")
+	if level == "" && message == "" {
+		return nil, false
+	}
+
+	entry := &LogEntry{
+		Timestamp: parseLogTimestamp(jsonLogField(fields, "@timestamp", "timestamp", "time")),
+		Level:     normalizeLogLevel(level),
+		Thread:    jsonLogField(fields, "thread_name", "thread", "process.thread.name"),
+		Logger:    jsonLogField(fields, "logger_name", "logger", "log.logger"),
+		Message:   message,
+		Raw:       raw,
+	}
+
+	if trace := jsonLogField(fields, "stack_trace", "error.stack_trace", "exception"); trace != "" {
+		entry.Trace = strings.Split(strings.TrimRight(trace, "\n"), "\n")
+	}
+
+	return entry, true
+}
+
+func jsonLogField(fields map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if v, ok := fields[key]; ok {
+			if s, ok := v.(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+func parseLogTimestamp(value string) time.Time {
+	value = strings.Replace(strings.TrimSpace(value), ",", ".", 1)
+	if value == "" {
+		return time.Time{}
+	}
+
+	for _, layout := range logTimestampLayouts {
+		ts, err := time.ParseInLocation(layout, value, time.Local)
+		if err != nil {
+			continue
+		}
+		// в паттерне только время — считаем, что это сегодняшняя запись
+		if ts.Year() == 0 {
+			now := time.Now()
+			ts = time.Date(now.Year(), now.Month(), now.Day(),
+				ts.Hour(), ts.Minute(), ts.Second(), ts.Nanosecond(), time.Local)
+		}
+		return ts
+	}
+
+	return time.Time{}
+}
+
+func normalizeLogLevel(level string) LogLevel {
+	switch strings.ToUpper(strings.TrimSpace(level)) {
+	case "TRACE":
+		return LogLevelTrace
+	case "DEBUG":
+		return LogLevelDebug
+	case "INFO":
+		return LogLevelInfo
+	case "WARN", "WARNING":
+		return LogLevelWarn
+	case "ERROR", "FATAL":
+		return LogLevelError
+	default:
+		return LogLevelUnknown
+	}
+}
+
+func stripAnsi(s string) string {
+	return ansiEscapeRe.ReplaceAllString(s, "")
+}
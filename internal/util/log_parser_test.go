@@ -0,0 +1,220 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseLogLine(t *testing.T) {
+	msk := time.FixedZone("", 3*60*60)
+
+	tests := []struct {
+		name string
+		line string
+		want *LogEntry
+	}{
+		{
+			name: "spring boot 3",
+			line: "2024-01-15T10:23:45.123+03:00  INFO 12345 --- [app] [main] c.e.d.DemoApplication : Started DemoApplication",
+			want: &LogEntry{
+				Timestamp: time.Date(2024, 1, 15, 10, 23, 45, 123e6, msk),
+				Level:     LogLevelInfo,
+				Thread:    "main",
+				Logger:    "c.e.d.DemoApplication",
+				Message:   "Started DemoApplication",
+			},
+		},
+		{
+			name: "spring boot 2 с выравниванием потока",
+			line: "2024-01-15 10:23:45.123  WARN 12345 --- [           main] o.s.b.StartupInfoLogger : slow start",
+			want: &LogEntry{
+				Timestamp: time.Date(2024, 1, 15, 10, 23, 45, 123e6, time.Local),
+				Level:     LogLevelWarn,
+				Thread:    "main",
+				Logger:    "o.s.b.StartupInfoLogger",
+				Message:   "slow start",
+			},
+		},
+		{
+			name: "spring с micrometer tracing и ANSI-цветами",
+			line: "\x1b[2m2024-01-15 10:23:45,123\x1b[0m ERROR [demo,65a1f0c2b3d4e5f6,a1b2c3d4] 12345 --- [nio-8080-exec-1] c.e.Api : failed",
+			want: &LogEntry{
+				Timestamp: time.Date(2024, 1, 15, 10, 23, 45, 123e6, time.Local),
+				Level:     LogLevelError,
+				Thread:    "nio-8080-exec-1",
+				Logger:    "c.e.Api",
+				Message:   "failed",
+			},
+		},
+		{
+			name: "logback с датой",
+			line: "2024-01-15 10:23:45.123 [main] WARNING c.e.Foo - disk almost full",
+			want: &LogEntry{
+				Timestamp: time.Date(2024, 1, 15, 10, 23, 45, 123e6, time.Local),
+				Level:     LogLevelWarn,
+				Thread:    "main",
+				Logger:    "c.e.Foo",
+				Message:   "disk almost full",
+			},
+		},
+		{
+			name: "logback без даты",
+			line: "10:23:45.123 [worker-1] FATAL c.e.Foo - boom",
+			want: &LogEntry{
+				Timestamp: todayAt(10, 23, 45, 123e6),
+				Level:     LogLevelError,
+				Thread:    "worker-1",
+				Logger:    "c.e.Foo",
+				Message:   "boom",
+			},
+		},
+		{
+			name: "json logstash encoder со стектрейсом",
+			line: `{"@timestamp":"2024-01-15T10:23:45.123+03:00","level":"ERROR","thread_name":"main","logger_name":"c.e.Foo","message":"boom","stack_trace":"java.lang.RuntimeException: boom\n\tat c.e.Foo.run(Foo.java:10)\n"}`,
+			want: &LogEntry{
+				Timestamp: time.Date(2024, 1, 15, 10, 23, 45, 123e6, msk),
+				Level:     LogLevelError,
+				Thread:    "main",
+				Logger:    "c.e.Foo",
+				Message:   "boom",
+				Trace:     []string{"java.lang.RuntimeException: boom", "\tat c.e.Foo.run(Foo.java:10)"},
+			},
+		},
+		{
+			name: "json ecs",
+			line: `{"@timestamp":"2024-01-15T07:23:45.123Z","log.level":"info","process.thread.name":"main","log.logger":"c.e.Foo","message":"ready"}`,
+			want: &LogEntry{
+				Timestamp: time.Date(2024, 1, 15, 7, 23, 45, 123e6, time.UTC),
+				Level:     LogLevelInfo,
+				Thread:    "main",
+				Logger:    "c.e.Foo",
+				Message:   "ready",
+			},
+		},
+		{name: "json без уровня и сообщения", line: `{"foo":"bar"}`},
+		{name: "битый json", line: `{"level":"INFO"`},
+		{name: "строка стектрейса", line: "\tat c.e.Foo.run(Foo.java:10)"},
+		{name: "пустая строка", line: "   "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseLogLine(tt.line)
+			if tt.want == nil {
+				if ok {
+					t.Fatalf("ParseLogLine(%q) = %+v, want no entry", tt.line, got)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("ParseLogLine(%q) not recognized", tt.line)
+			}
+			tt.want.Raw = tt.line
+			assertLogEntry(t, *got, *tt.want)
+		})
+	}
+}
+
+func TestLogParserFeed(t *testing.T) {
+	const (
+		header1 = "2024-01-15 10:23:45.123 ERROR 1 --- [main] c.e.Foo : boom"
+		header2 = "2024-01-15 10:23:46.000  INFO 1 --- [main] c.e.Foo : next"
+		trace1  = "java.lang.RuntimeException: boom"
+		trace2  = "\tat c.e.Foo.run(Foo.java:10)"
+	)
+
+	tests := []struct {
+		name    string
+		batches [][]string
+		// flush - вызвать Flush после каждой пачки (как tail по простою)
+		flush bool
+		want  []LogEntry
+	}{
+		{
+			name:    "стектрейс сворачивается в предыдущую запись",
+			batches: [][]string{{header1, trace1, trace2, header2}},
+			want: []LogEntry{
+				{Level: LogLevelError, Message: "boom", Raw: header1, Trace: []string{trace1, trace2}},
+				{Level: LogLevelInfo, Message: "next", Raw: header2},
+			},
+		},
+		{
+			name:    "стектрейс разорван между пачками",
+			batches: [][]string{{header1, trace1}, {trace2, header2}},
+			want: []LogEntry{
+				{Level: LogLevelError, Message: "boom", Raw: header1, Trace: []string{trace1, trace2}},
+				{Level: LogLevelInfo, Message: "next", Raw: header2},
+			},
+		},
+		{
+			name:    "строки без заголовка до первой записи",
+			batches: [][]string{{"  .   ____", header2}},
+			want: []LogEntry{
+				{Level: LogLevelUnknown, Message: "  .   ____", Raw: "  .   ____"},
+				{Level: LogLevelInfo, Message: "next", Raw: header2},
+			},
+		},
+		{
+			name:    "продолжение после отдачи по простою",
+			batches: [][]string{{header1, trace1}, {trace2}, {header2}},
+			flush:   true,
+			want: []LogEntry{
+				{Level: LogLevelError, Message: "boom", Raw: header1, Trace: []string{trace1}},
+				{Level: LogLevelError, Message: "boom", Raw: header1, Trace: []string{trace2}, Continuation: true},
+				{Level: LogLevelInfo, Message: "next", Raw: header2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewLogParser()
+			var got []LogEntry
+			for _, batch := range tt.batches {
+				got = append(got, p.Feed(batch)...)
+				if tt.flush {
+					got = append(got, p.Flush()...)
+				}
+			}
+			got = append(got, p.Flush()...)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d entries %+v, want %d", len(got), got, len(tt.want))
+			}
+			for i := range got {
+				// время и поток проверяет TestParseLogLine
+				got[i].Timestamp, got[i].Thread, got[i].Logger = time.Time{}, "", ""
+				assertLogEntry(t, got[i], tt.want[i])
+			}
+		})
+	}
+}
+
+func TestLogParserReset(t *testing.T) {
+	p := NewLogParser()
+	p.Feed([]string{"2024-01-15 10:23:45.123 ERROR 1 --- [main] c.e.Foo : boom"})
+	p.Flush()
+	p.Reset()
+
+	got := p.Feed([]string{"\tat c.e.Foo.run(Foo.java:10)"})
+	if len(got) != 1 || got[0].Level != LogLevelUnknown || got[0].Continuation {
+		t.Fatalf("after Reset got %+v, want a single UNKNOWN entry", got)
+	}
+}
+
+func assertLogEntry(t *testing.T, got, want LogEntry) {
+	t.Helper()
+	if !got.Timestamp.Equal(want.Timestamp) {
+		t.Errorf("Timestamp = %v, want %v", got.Timestamp, want.Timestamp)
+	}
+	got.Timestamp, want.Timestamp = time.Time{}, time.Time{}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("entry = %+v, want %+v", got, want)
+	}
+}
+
+func todayAt(hour, minute, sec, nsec int) time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), hour, minute, sec, nsec, time.Local)
+}
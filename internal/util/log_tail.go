@@ -2,10 +2,12 @@ package util
 
 import (
 	"bufio"
+	"central-desktop/internal/domain"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -26,10 +28,12 @@ func NewLogTailer() *LogTailer {
 // Start всегда читает файл с начала, затем "следит" за добавлением новых строк.
 // События:
 // - opt.LinesEventName (default "log:lines") -> payload: []string
+// - "log:entries" -> payload: []LogEntry (разобранные записи, стектрейсы свёрнуты в Trace;
+// записи с Continuation дописывают Trace предыдущей записи)
 // - "log:error" -> payload: string
 // - "log:started" -> payload: string (path)
 // - "log:stopped" -> payload: nil
-func (t *LogTailer) Start(ctx context.Context, logPath string) error {
+func (t *LogTailer) Start(ctx context.Context, logPath string, enc domain.OutputEncoding) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
@@ -40,9 +44,9 @@ func (t *LogTailer) Start(ctx context.Context, logPath string) error {
 		return fmt.Errorf("path is empty")
 	}
 
-	pollIntervalMs := 200
 	maxLinesPerEmit := 2000
 	linesEventName := "log:lines"
+	entriesEventName := "log:entries"
 
 	tctx, cancel := context.WithCancel(ctx)
 	t.cancel = cancel
@@ -59,131 +63,230 @@ func (t *LogTailer) Start(ctx context.Context, logPath string) error {
 			runtime.EventsEmit(ctx, "log:stopped")
 		}()
 
-		var offset int64 = 0
-		var carry string
+		parser := NewLogParser()
 
-		file, err := openFile(logPath)
-		if err != nil {
-			file = nil
-			// файл может появиться чуть позже — продолжаем ретраить
-			runtime.EventsEmit(ctx, "log:error", fmt.Sprintf("open log file: %v", err))
+		followLogFile(tctx, logPath, logFollowOptions{
+			encoding: enc,
+			onLines: func(lines []string) {
+				for i := 0; i < len(lines); i += maxLinesPerEmit {
+					j := i + maxLinesPerEmit
+					if j > len(lines) {
+						j = len(lines)
+					}
+					runtime.EventsEmit(ctx, linesEventName, lines[i:j])
+				}
+
+				emitLogEntries(ctx, entriesEventName, parser.Feed(lines), maxLinesPerEmit)
+			},
+			// новых данных давно нет — отдаём последнюю запись, стектрейс к ней уже не допишется
+			onIdle: func() {
+				emitLogEntries(ctx, entriesEventName, parser.Flush(), maxLinesPerEmit)
+			},
+			onReset: parser.Reset,
+			onError: func(msg string) {
+				runtime.EventsEmit(ctx, "log:error", msg)
+			},
+		})
+	}()
+
+	return nil
+}
+
+func emitLogEntries(ctx context.Context, eventName string, entries []LogEntry, maxPerEmit int) {
+	for i := 0; i < len(entries); i += maxPerEmit {
+		j := i + maxPerEmit
+		if j > len(entries) {
+			j = len(entries)
 		}
+		runtime.EventsEmit(ctx, eventName, entries[i:j])
+	}
+}
 
-		ticker := time.NewTicker(time.Duration(pollIntervalMs) * time.Millisecond)
-		defer ticker.Stop()
+func (t *LogTailer) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 
-		for {
-			select {
-			case <-tctx.Done():
-				if file != nil {
-					_ = file.Close()
-				}
-				return
-
-			case <-ticker.C:
-				// если файл не открыт — пробуем открыть снова и читаем с начала
-				if file == nil {
-					f, oerr := openFile(logPath)
-					if oerr != nil {
-						continue
-					}
-					file = f
-					offset = 0
-					carry = ""
-				}
+	if t.cancel != nil {
+		t.cancel()
+	}
+}
 
-				st, serr := file.Stat()
-				if serr != nil {
-					runtime.EventsEmit(ctx, "log:error", fmt.Sprintf("stat log file: %v", serr))
-					_ = file.Close()
-					file = nil
-					continue
-				}
+const (
+	logPollInterval = 200 * time.Millisecond
+	// logIdleFlushDelay - сколько ждать после последних данных, прежде чем считать последнюю запись
+	// законченной: строки стектрейса могут прийти позже одного интервала опроса
+	logIdleFlushDelay = 2 * time.Second
+)
 
-				// truncate / rotation: файл стал меньше — читаем заново с начала
-				if st.Size() < offset {
-					offset = 0
-					carry = ""
-				}
+type logFollowOptions struct {
+	// startAtEnd - при первом открытии пропустить уже записанное содержимое файла
+	startAtEnd bool
+	// encoding - кодировка файла, строки перед onLines переводятся в UTF-8
+	encoding domain.OutputEncoding
+	// onLines получает только завершённые строки (без '\n')
+	onLines func(lines []string)
+	// onIdle вызывается один раз, когда новых данных нет дольше logIdleFlushDelay
+	onIdle func()
+	// onReset вызывается, когда файл перечитывается с начала (переоткрытие, усечение)
+	onReset func()
+	onError func(msg string)
+}
+
+func (h logFollowOptions) reportError(msg string) {
+	if h.onError != nil {
+		h.onError(msg)
+	}
+}
+
+// followLogFile читает файл с начала (или с конца, см. startAtEnd) и следит за дописыванием строк, пока не отменён ctx.
+// Блокирующая: вызывается из отдельной горутины.
+func followLogFile(ctx context.Context, logPath string, h logFollowOptions) {
+	var offset int64 = 0
+	var carry string
+	lastData := time.Now()
+	idleNotified := false
+	// prevInfo - последний открытый файл: после ошибки чтения тот же файл переоткрывается
+	// с прежнего offset, чтобы не обработать (и не оповестить) старые строки повторно
+	var prevInfo os.FileInfo
+
+	reset := func() {
+		offset = 0
+		carry = ""
+		if h.onReset != nil {
+			h.onReset()
+		}
+	}
+
+	file, err := openFile(logPath)
+	if err != nil {
+		file = nil
+		// файл может появиться чуть позже — продолжаем ретраить
+		h.reportError(fmt.Sprintf("open log file: %v", err))
+	} else if st, serr := file.Stat(); serr == nil {
+		prevInfo = st
+		if h.startAtEnd {
+			offset = st.Size()
+		}
+	}
+
+	ticker := time.NewTicker(logPollInterval)
+	defer ticker.Stop()
 
-				// новых данных нет
-				if st.Size() == offset {
+	for {
+		select {
+		case <-ctx.Done():
+			if file != nil {
+				_ = file.Close()
+			}
+			return
+
+		case <-ticker.C:
+			// если файл не открыт — пробуем открыть снова; новый файл читаем с начала
+			if file == nil {
+				f, oerr := openFile(logPath)
+				if oerr != nil {
 					continue
 				}
+				file = f
+				st, serr := file.Stat()
+				if serr != nil || prevInfo == nil || !os.SameFile(prevInfo, st) {
+					reset()
+				}
+				if serr == nil {
+					prevInfo = st
+				}
+			}
 
-				if _, serr = file.Seek(offset, io.SeekStart); serr != nil {
-					runtime.EventsEmit(ctx, "log:error", fmt.Sprintf("seek log file: %v", serr))
-					_ = file.Close()
-					file = nil
-					continue
+			st, serr := file.Stat()
+			if serr != nil {
+				h.reportError(fmt.Sprintf("stat log file: %v", serr))
+				_ = file.Close()
+				file = nil
+				continue
+			}
+
+			// файл пересоздан (перезапуск в quiet mode удаляет старый лог) — переоткрываем
+			if pst, perr := os.Stat(logPath); perr == nil && !os.SameFile(st, pst) {
+				_ = file.Close()
+				file = nil
+				continue
+			}
+
+			// truncate / rotation: файл стал меньше — читаем заново с начала
+			if st.Size() < offset {
+				reset()
+			}
+
+			// новых данных нет
+			if st.Size() == offset {
+				if h.onIdle != nil && !idleNotified && time.Since(lastData) >= logIdleFlushDelay {
+					idleNotified = true
+					h.onIdle()
 				}
+				continue
+			}
+			lastData = time.Now()
+			idleNotified = false
+
+			if _, serr = file.Seek(offset, io.SeekStart); serr != nil {
+				h.reportError(fmt.Sprintf("seek log file: %v", serr))
+				_ = file.Close()
+				file = nil
+				continue
+			}
 
-				reader := bufio.NewReader(file)
-				var sb strings.Builder
-
-				// читаем всё до EOF
-				for {
-					part, rerr := reader.ReadString('\n')
-					sb.WriteString(part)
-					if rerr != nil {
-						if errors.Is(rerr, io.EOF) {
-							break
-						}
-						runtime.EventsEmit(ctx, "log:error", fmt.Sprintf("read log file: %v", rerr))
-						_ = file.Close()
-						file = nil
+			reader := bufio.NewReader(file)
+			var sb strings.Builder
+
+			// читаем всё до EOF
+			for {
+				part, rerr := reader.ReadString('\n')
+				sb.WriteString(part)
+				if rerr != nil {
+					if errors.Is(rerr, io.EOF) {
 						break
 					}
+					h.reportError(fmt.Sprintf("read log file: %v", rerr))
+					_ = file.Close()
+					file = nil
+					break
 				}
-				if file == nil {
-					continue
-				}
+			}
+			if file == nil {
+				continue
+			}
 
-				// обновляем offset на текущее положение (конец прочитанного)
-				offset, _ = file.Seek(0, io.SeekCurrent)
+			// обновляем offset на текущее положение (конец прочитанного)
+			offset, _ = file.Seek(0, io.SeekCurrent)
 
-				text := carry + sb.String()
+			text := carry + sb.String()
 
-				// сохраним незавершённую строку
-				if !strings.HasSuffix(text, "\n") {
-					if idx := strings.LastIndexByte(text, '\n'); idx >= 0 {
-						carry = text[idx+1:]
-						text = text[:idx+1]
-					} else {
-						carry = text
-						continue
-					}
+			// сохраним незавершённую строку
+			if !strings.HasSuffix(text, "\n") {
+				if idx := strings.LastIndexByte(text, '\n'); idx >= 0 {
+					carry = text[idx+1:]
+					text = text[:idx+1]
 				} else {
-					carry = ""
-				}
-
-				lines := strings.Split(text, "\n")
-				if len(lines) > 0 && lines[len(lines)-1] == "" {
-					lines = lines[:len(lines)-1]
-				}
-				if len(lines) == 0 {
+					carry = text
 					continue
 				}
-
-				for i := 0; i < len(lines); i += maxLinesPerEmit {
-					j := i + maxLinesPerEmit
-					if j > len(lines) {
-						j = len(lines)
-					}
-					runtime.EventsEmit(ctx, linesEventName, lines[i:j])
-				}
+			} else {
+				carry = ""
 			}
-		}
-	}()
 
-	return nil
-}
+			lines := strings.Split(text, "\n")
+			if len(lines) > 0 && lines[len(lines)-1] == "" {
+				lines = lines[:len(lines)-1]
+			}
+			if len(lines) == 0 {
+				continue
+			}
 
-func (t *LogTailer) Stop() {
-	t.mu.Lock()
-	defer t.mu.Unlock()
+			for i := range lines {
+				lines[i] = DecodeLogLine(h.encoding, lines[i])
+			}
 
-	if t.cancel != nil {
-		t.cancel()
+			h.onLines(lines)
+		}
 	}
 }
@@ -0,0 +1,58 @@
+package util
+
+import (
+	"context"
+	"sync"
+)
+
+// LogWatcher фоново следит за лог-файлами приложений, не отправляя строки в UI.
+// Уже записанное содержимое пропускается: обрабатываются только новые строки
+// (и целиком новый файл, если лог был пересоздан при перезапуске приложения).
+type LogWatcher struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewLogWatcher() *LogWatcher {
+	return &LogWatcher{}
+}
+
+// Watch останавливает предыдущее слежение и начинает следить за sources.
+// onLines вызывается из горутины конкретного источника.
+func (w *LogWatcher) Watch(ctx context.Context, sources []LogSource, onLines func(src LogSource, lines []string)) {
+	w.Stop()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	wctx, cancel := context.WithCancel(ctx)
+	w.cancel = cancel
+
+	for _, src := range sources {
+		w.wg.Add(1)
+		go func(src LogSource) {
+			defer w.wg.Done()
+
+			followLogFile(wctx, src.Path, logFollowOptions{
+				startAtEnd: true,
+				encoding:   src.Encoding,
+				onLines: func(lines []string) {
+					onLines(src, lines)
+				},
+			})
+		}(src)
+	}
+}
+
+func (w *LogWatcher) Stop() {
+	w.mu.Lock()
+	cancel := w.cancel
+	w.cancel = nil
+	w.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	w.wg.Wait()
+}
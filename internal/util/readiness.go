@@ -0,0 +1,96 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	springStartedRe = regexp.MustCompile(`Started \S+ in \d+(?:[.,]\d+)? seconds`)
+	springFailedRe  = regexp.MustCompile(`APPLICATION FAILED TO START`)
+)
+
+// IsPortListenedBy - TCP порт port слушает процесс pid. Проверяется владелец порта,
+// а не доступность соединения: порт мог остаться занят другим процессом.
+func IsPortListenedBy(port int, pid int) (bool, error) {
+	pids, err := listeningPIDs(port)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range pids {
+		if p == pid {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// listeningPIDs возвращает процессы, слушающие TCP порт port (по "netstat -ano").
+// Строки вида "TCP  0.0.0.0:8080  0.0.0.0:0  LISTENING  1234" (и TCPv6); состояние не сравнивается,
+// так как оно может быть локализовано: у слушающего сокета удалённый порт равен 0.
+func listeningPIDs(port int) ([]int, error) {
+	cmd := exec.Command("netstat", "-ano")
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		HideWindow:    true,
+		CreationFlags: windows.CREATE_NO_WINDOW,
+	}
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("netstat failed: %w", err)
+	}
+
+	suffix := ":" + strconv.Itoa(port)
+	var res []int
+	for _, line := range strings.Split(out.String(), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 || !strings.HasPrefix(fields[0], "TCP") {
+			continue
+		}
+		if !strings.HasSuffix(fields[1], suffix) || !strings.HasSuffix(fields[2], ":0") {
+			continue
+		}
+		if pid, err := strconv.Atoi(fields[len(fields)-1]); err == nil {
+			res = append(res, pid)
+		}
+	}
+	return res, nil
+}
+
+// LogReadiness ищет в логе приложения итог запуска Spring Boot:
+// ready - "Started ... in ... seconds", failed - "APPLICATION FAILED TO START".
+func LogReadiness(logPath string) (ready bool, failed bool) {
+	f, err := os.Open(logPath)
+	if err != nil {
+		return false, false
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if springFailedRe.MatchString(line) {
+			return false, true
+		}
+		if springStartedRe.MatchString(line) {
+			ready = true
+		}
+	}
+	return ready, false
+}
@@ -0,0 +1,93 @@
+package util
+
+import (
+	"central-desktop/internal/domain"
+	"regexp"
+	"strings"
+)
+
+const redactedValue = "******"
+
+var (
+	secretNameRe = regexp.MustCompile(`(?i)(pass(word|wd)?|pwd|secret|token|credential|api[_.-]?key|private[_.-]?key|auth)`)
+	// urlUserInfoRe - scheme://user:password@ в URL (jdbc:postgresql://, https:// и т.п.)
+	urlUserInfoRe = regexp.MustCompile(`([A-Za-z][A-Za-z0-9+.\-]*://)([^/@\s:]+):([^/@\s]*)@`)
+	// embeddedPropRe - name=value внутри значения: -Dname=value среди других аргументов, параметры в URL
+	embeddedPropRe = regexp.MustCompile(`([A-Za-z_][\w.\-]*)=([^\s"';,&]+)`)
+	// jsonEnvVarRe - domain.EnvVariable в JSON: {"name":"...","value":"..."}
+	jsonEnvVarRe = regexp.MustCompile(`("name"\s*:\s*"((?:[^"\\]|\\.)*)"\s*,\s*"value"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// jsonFieldRe - строковое поле JSON "key":"value"
+	jsonFieldRe = regexp.MustCompile(`("((?:[^"\\]|\\.)*)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// IsSecretName - похоже ли имя переменной/свойства на секрет.
+func IsSecretName(name string) bool {
+	return secretNameRe.MatchString(name)
+}
+
+// RedactEnv возвращает активные переменные в виде NAME=VALUE со скрытыми значениями секретов.
+func RedactEnv(vars []domain.EnvVariable) []string {
+	out := make([]string, 0, len(vars))
+	for _, v := range vars {
+		name := strings.TrimSpace(v.Name)
+		if name == "" || !v.IsActive {
+			continue
+		}
+		value := RedactText(v.Value)
+		if IsSecretName(name) {
+			value = redactedValue
+		}
+		out = append(out, name+"="+value)
+	}
+	return out
+}
+
+// RedactArgs скрывает значения -Dname=value и --name=value, если имя похоже на секрет,
+// а также секреты внутри значений (см. RedactText).
+func RedactArgs(args []string) []string {
+	out := make([]string, len(args))
+	for i, a := range args {
+		out[i] = RedactText(a)
+		if !strings.HasPrefix(a, "-") {
+			continue
+		}
+		name, _, ok := strings.Cut(a, "=")
+		if ok && IsSecretName(name) {
+			out[i] = name + "=" + redactedValue
+		}
+	}
+	return out
+}
+
+// RedactText скрывает пароль в URL (user:password@) и значения name=value с похожим на секрет именем
+// внутри произвольной строки, например в "-Xmx1g -Dspring.datasource.password=..." или строке подключения.
+func RedactText(s string) string {
+	s = urlUserInfoRe.ReplaceAllString(s, "${1}${2}:"+redactedValue+"@")
+	return embeddedPropRe.ReplaceAllStringFunc(s, func(m string) string {
+		name, _, _ := strings.Cut(m, "=")
+		if IsSecretName(name) {
+			return name + "=" + redactedValue
+		}
+		return m
+	})
+}
+
+// RedactLogLine скрывает секреты в строке лога JAC (JSON slog): значения полей и переменных окружения
+// с похожим на секрет именем, а также секреты внутри строк (см. RedactText).
+func RedactLogLine(line string) string {
+	line = jsonEnvVarRe.ReplaceAllStringFunc(line, func(m string) string {
+		sub := jsonEnvVarRe.FindStringSubmatch(m)
+		if IsSecretName(sub[2]) {
+			return sub[1] + `"` + redactedValue + `"`
+		}
+		return m
+	})
+	line = jsonFieldRe.ReplaceAllStringFunc(line, func(m string) string {
+		sub := jsonFieldRe.FindStringSubmatch(m)
+		if IsSecretName(sub[2]) {
+			return sub[1] + `"` + redactedValue + `"`
+		}
+		return m
+	})
+	return RedactText(line)
+}
@@ -0,0 +1,48 @@
+package util
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// toastAppID - AppUserModelID PowerShell: уведомление без регистрации собственного ярлыка в меню Пуск
+const toastAppID = `{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe`
+
+// toastScript показывает toast через WinRT. Текст передаётся через переменные окружения,
+// чтобы не экранировать его для командной строки PowerShell.
+const toastScript = `
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] | Out-Null
+$title = [Security.SecurityElement]::Escape($env:JAC_TOAST_TITLE)
+$message = [Security.SecurityElement]::Escape($env:JAC_TOAST_MESSAGE)
+$xml = New-Object Windows.Data.Xml.Dom.XmlDocument
+$xml.LoadXml("<toast><visual><binding template='ToastGeneric'><text>$title</text><text>$message</text></binding></visual></toast>")
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($env:JAC_TOAST_APP_ID).Show([Windows.UI.Notifications.ToastNotification]::new($xml))
+`
+
+// ShowToast показывает системное уведомление Windows, видимое и при свёрнутом в трей окне.
+// Не ждёт, пока уведомление будет показано.
+func ShowToast(title, message string) error {
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-ExecutionPolicy", "Bypass",
+		"-Command", toastScript)
+	cmd.Env = append(os.Environ(),
+		"JAC_TOAST_TITLE="+title,
+		"JAC_TOAST_MESSAGE="+message,
+		"JAC_TOAST_APP_ID="+toastAppID,
+	)
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		HideWindow:    true,
+		CreationFlags: windows.CREATE_NO_WINDOW,
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() {
+		_ = cmd.Wait()
+	}()
+	return nil
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"central-desktop/internal/domain"
 	"central-desktop/internal/util"
 	"context"
 	"embed"
+	"fmt"
 	"io"
 	"log"
 	"log/slog"
@@ -105,6 +107,19 @@ func main() {
 			systray.SetOnRClick(func(menu systray.IMenu) {
 				_ = menu.ShowMenu()
 			})
+
+			// оповещение по логам показывается системным уведомлением, последнее видно в подсказке иконки
+			app.deps.Services.AlertService.OnAlert(func(alert domain.LogAlert) {
+				if err := util.ShowToast(fmt.Sprintf("%s: %s", alert.AppName, alert.RuleName), alert.Line); err != nil {
+					slogger.Warn("failed to show alert notification", "app", alert.AppName, "err", err)
+				}
+
+				tooltip := fmt.Sprintf("Java Application Center\n%s: %s", alert.AppName, alert.RuleName)
+				if r := []rune(tooltip); len(r) > 127 {
+					tooltip = string(r[:127])
+				}
+				systray.SetTooltip(tooltip)
+			})
 		}, func() {})
 	}
 